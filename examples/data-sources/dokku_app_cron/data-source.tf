@@ -0,0 +1,13 @@
+data "dokku_app_cron" "demo" {
+  app_name = dokku_app.demo.app_name
+
+  depends_on = [dokku_app.demo]
+}
+
+# Check that cron tasks from app.json are scheduled after deploy
+check "cron_tasks" {
+  assert {
+    condition     = contains(data.dokku_app_cron.demo.tasks[*].command, "npm run cleanup")
+    error_message = "Cleanup cron task is not scheduled"
+  }
+}
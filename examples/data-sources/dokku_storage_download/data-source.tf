@@ -0,0 +1,17 @@
+# Snapshot uploaded media to local directory
+data "dokku_storage_download" "uploads" {
+  storage         = "uploads"
+  path            = "media"
+  local_directory = "./backup/media"
+  max_size_bytes  = 104857600
+}
+
+# Read single file into attribute
+data "dokku_storage_download" "settings" {
+  storage = "config"
+  path    = "settings.yaml"
+}
+
+output "settings" {
+  value = data.dokku_storage_download.settings.content
+}
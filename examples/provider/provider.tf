@@ -25,3 +25,9 @@ provider "dokku" {
   ssh_cert     = var.ssh_cert
   ssh_host_key = "127.0.0.1 ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQCql...Dq+Nnpue8="
 }
+
+# wait up to 10 minutes if app is locked by another deploy
+provider "dokku" {
+  ssh_host                  = "127.0.0.1"
+  lock_wait_timeout_seconds = 600
+}
@@ -1,2 +1,3 @@
 # dokku_app can be imported by specifying the app name
+# All settings of app are imported. Configuration for them could be generated using import block and "terraform plan -generate-config-out=generated.tf"
 terraform import dokku_app.demo app_name
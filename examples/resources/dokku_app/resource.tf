@@ -9,6 +9,22 @@ resource "dokku_app" "demo2" {
   config = {
     foo = "bar"
   }
+  # remove env vars that are not set in config, i.e. added manually using config:set
+  config_mode = "authoritative"
+
+  # values from .env files, values set in config, sensitive_config and config_wo take precedence
+  config_files = ["./.env", "./.env.production"]
+
+  # values are not displayed in plan output
+  sensitive_config = {
+    DATABASE_PASSWORD = var.database_password
+    TLS_KEY           = file("./tls.key")
+  }
+
+  # values are not saved to state (requires Terraform 1.11 or later)
+  config_wo = {
+    API_KEY = var.api_key
+  }
 
   # https://dokku.com/docs/deployment/zero-downtime-deploys/
   checks = {
@@ -61,6 +77,48 @@ resource "dokku_app" "demo2" {
     attach_post_create = "internal"
   }
 
+  # https://dokku.com/docs/processes/process-management/#scaling-apps
+  processes = {
+    web    = 2
+    worker = 1
+  }
+
+  # https://dokku.com/docs/processes/process-management/#restart-policies
+  restart_policy       = "on-failure:5"
+  stop_timeout_seconds = 60
+
+  # https://dokku.com/docs/advanced-usage/resource-management/
+  resources = {
+    web = {
+      limit = {
+        cpu    = "1"
+        memory = "512m"
+      }
+      reserve = {
+        memory = "256m"
+      }
+    }
+  }
+
+  # https://dokku.com/docs/deployment/builders/builder-management/
+  build = {
+    builder         = "dockerfile"
+    dockerfile_path = "docker/Dockerfile.prod"
+    build_args = {
+      NODE_ENV = "production"
+    }
+    sensitive_build_args = {
+      NPM_TOKEN = var.npm_token
+    }
+  }
+
+  # https://dokku.com/docs/deployment/builders/herokuish-buildpacks/
+  buildpacks = [
+    "https://github.com/heroku/heroku-buildpack-nodejs.git",
+    "https://github.com/heroku/heroku-buildpack-python.git",
+  ]
+  buildpack_stack = "gliderlabs/herokuish:latest-22"
+
   # https://dokku.com/docs/deployment/methods/git/
   # https://dokku.com/docs/deployment/methods/image/
   # https://dokku.com/docs/deployment/methods/archive/
@@ -71,3 +129,60 @@ resource "dokku_app" "demo2" {
     docker_image = var.docker_image
   }
 }
+
+resource "dokku_app" "demo3" {
+  app_name = "demo3"
+
+  # Prevent deploys by git push, app is deployed only by terraform
+  # https://dokku.com/docs/deployment/application-management/#locking-app-deploys
+  locked = true
+
+  # Revert already applied changes and redeploy previous image if update fails
+  rollback_on_failure = true
+
+  # Deploy without docker registry, i.e. to air-gapped host
+  # Archive could be created using "docker save my-image:1.0.0 -o my-image.tar"
+  deploy = {
+    type                      = "docker_image_archive"
+    docker_image              = "my-image:1.0.0"
+    docker_image_archive_path = "./my-image.tar"
+  }
+}
+
+resource "dokku_app" "demo4" {
+  app_name = "demo4"
+
+  # Change of app_name renames app instead of recreating it
+  rename_in_place = true
+
+  # Build app on host from local directory. Files excluded by .dockerignore are not uploaded.
+  # App is redeployed only if content of directory is changed
+  deploy = {
+    type              = "local_source"
+    local_source_path = "./app"
+  }
+
+  # https://dokku.com/docs/deployment/zero-downtime-deploys/
+  checks = {
+    disabled       = ["worker"]
+    wait_to_retire = 30
+    attempts       = 10
+    timeout        = 10
+    wait           = 3
+  }
+}
+
+resource "dokku_app" "demo5" {
+  app_name = "demo5"
+
+  # Deploy one service of monorepo using its own Procfile and app.json
+  # https://dokku.com/docs/processes/process-management/#changing-the-procfile-location
+  # https://dokku.com/docs/advanced-usage/deployment-tasks/#changing-the-appjson-location
+  procfile_path = "services/api/Procfile"
+  app_json_path = "services/api/app.json"
+
+  deploy = {
+    type           = "git_repository"
+    git_repository = "https://github.com/example/monorepo.git"
+  }
+}
@@ -0,0 +1,3 @@
+# dokku_app_config can be imported by specifying the app name
+# All config keys except internal DOKKU_* ones will be imported
+terraform import dokku_app_config.demo 'demo'
@@ -0,0 +1,6 @@
+resource "dokku_app_config" "demo" {
+  app_name = "demo"
+  config = {
+    KEY = "value"
+  }
+}
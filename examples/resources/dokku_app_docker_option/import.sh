@@ -0,0 +1,2 @@
+# dokku_app_docker_option can be imported by specifying the app name, comma-separated phases and option separated by colon
+terraform import dokku_app_docker_option.demo 'demo:deploy,run:--init'
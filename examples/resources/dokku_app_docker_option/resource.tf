@@ -0,0 +1,5 @@
+resource "dokku_app_docker_option" "demo" {
+  app_name = "demo"
+  option   = "--init"
+  phase    = ["deploy", "run"]
+}
@@ -0,0 +1,4 @@
+resource "dokku_app_domains" "demo" {
+  app_name = "demo"
+  domains  = ["demo.example.com"]
+}
@@ -0,0 +1,4 @@
+resource "dokku_app_network" "demo" {
+  app_name           = "demo"
+  attach_post_deploy = "demo-network"
+}
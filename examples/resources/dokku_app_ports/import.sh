@@ -0,0 +1,2 @@
+# dokku_app_ports can be imported by specifying the app name
+terraform import dokku_app_ports.demo 'demo'
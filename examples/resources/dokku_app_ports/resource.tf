@@ -0,0 +1,9 @@
+resource "dokku_app_ports" "demo" {
+  app_name = "demo"
+  ports = {
+    80 = {
+      scheme         = "http"
+      container_port = 5000
+    }
+  }
+}
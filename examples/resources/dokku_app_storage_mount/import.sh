@@ -0,0 +1,2 @@
+# dokku_app_storage_mount can be imported by specifying the app name and storage name separated by colon
+terraform import dokku_app_storage_mount.demo 'demo:demo-data'
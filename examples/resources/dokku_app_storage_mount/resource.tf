@@ -0,0 +1,9 @@
+resource "dokku_storage" "data" {
+  name = "demo-data"
+}
+
+resource "dokku_app_storage_mount" "demo" {
+  app_name   = "demo"
+  storage    = dokku_storage.data.name
+  mount_path = "/app/data"
+}
@@ -0,0 +1,3 @@
+# dokku_global_config can be imported by specifying any ID
+# All global config keys will be imported
+terraform import dokku_global_config.default 'global'
@@ -0,0 +1,6 @@
+resource "dokku_global_config" "default" {
+  config = {
+    DOKKU_LETSENCRYPT_EMAIL     = "admin@example.com"
+    OTEL_EXPORTER_OTLP_ENDPOINT = "http://otel-collector:4317"
+  }
+}
@@ -0,0 +1,31 @@
+# Run database migrations after each deploy
+resource "dokku_run" "migrate" {
+  app_name = dokku_app.demo.app_name
+  command  = "npm run migrate"
+
+  triggers = {
+    deployed_at = dokku_app.demo.deployed_at
+  }
+
+  redact = [var.database_password]
+}
+
+# Seed database once
+resource "dokku_run" "seed" {
+  app_name           = dokku_app.demo.app_name
+  command            = "npm run seed"
+  run_on_create_only = true
+
+  depends_on = [dokku_run.migrate]
+}
+
+# Run cron task on demand
+resource "dokku_run" "cleanup" {
+  app_name = dokku_app.demo.app_name
+  cron_id  = one([for task in data.dokku_app_cron.demo.tasks : task.id if task.command == "npm run cleanup"])
+  detached = true
+
+  triggers = {
+    deployed_at = dokku_app.demo.deployed_at
+  }
+}
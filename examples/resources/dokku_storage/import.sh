@@ -0,0 +1,2 @@
+# dokku_storage can be imported by specifying the storage name
+terraform import dokku_storage.shared storage_name
@@ -0,0 +1,29 @@
+resource "dokku_storage" "shared" {
+  name  = "shared-uploads"
+  chown = "heroku"
+
+  # remove directory from host on destroy
+  delete_on_destroy = true
+}
+
+# Use host_path to mount the same storage to multiple apps.
+# It also makes dependency on dokku_storage explicit.
+resource "dokku_app" "web" {
+  app_name = "web"
+
+  storage = {
+    (dokku_storage.shared.host_path) = {
+      mount_path = "/app/uploads"
+    }
+  }
+}
+
+resource "dokku_app" "worker" {
+  app_name = "worker"
+
+  storage = {
+    (dokku_storage.shared.host_path) = {
+      mount_path = "/app/uploads"
+    }
+  }
+}
@@ -0,0 +1,14 @@
+resource "dokku_storage_file" "nginx_conf" {
+  storage = "config"
+  path    = "nginx/nginx.conf"
+  content = templatefile("${path.module}/nginx.conf.tftpl", {
+    upstream = "web"
+  })
+}
+
+resource "dokku_storage_file" "tls_bundle" {
+  storage           = dokku_storage.certs.host_path
+  path              = "bundle.pem"
+  sensitive_content = var.tls_bundle
+  mode              = "0600"
+}
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource                = &appConfigResource{}
+	_ resource.ResourceWithConfigure   = &appConfigResource{}
+	_ resource.ResourceWithImportState = &appConfigResource{}
+)
+
+func NewAppConfigResource() resource.Resource {
+	return &appConfigResource{}
+}
+
+type appConfigResource struct {
+	client *dokkuclient.Client
+}
+
+type appConfigResourceModel struct {
+	AppName types.String            `tfsdk:"app_name"`
+	Config  map[string]types.String `tfsdk:"config"`
+}
+
+// Metadata returns the resource type name.
+func (r *appConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_config"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *appConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *appConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Config (env vars) for existing app",
+			"Only keys set here are managed, so it can be used together with dokku_app.config attribute or other dokku_app_config resources for different keys",
+			"https://dokku.com/docs/configuration/environment-variables/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of application to set config for",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "invalid app_name"),
+				},
+			},
+			"config": schema.MapAttribute{
+				Required:    true,
+				Description: "Config (env vars) for app",
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`), "invalid name")),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *appConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state appConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check app existence
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	imported, diags := req.Private.GetKey(ctx, appImportedPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.ConfigExport(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to get config", "Unable to get config. "+err.Error())
+		return
+	}
+	cfg := make(map[string]types.String)
+	for k, v := range config {
+		_, known := state.Config[k]
+		// only known keys, or all keys except internal ones on import
+		if known || (imported != nil && !strings.HasPrefix(k, "DOKKU_")) {
+			cfg[k] = basetypes.NewStringValue(v)
+		}
+	}
+	state.Config = cfg
+
+	if imported != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, appImportedPrivateKey, nil)...)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *appConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan appConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := make(map[string]string)
+	for k, v := range plan.Config {
+		config[k] = v.ValueString()
+	}
+	err := r.client.ConfigSet(ctx, plan.AppName.ValueString(), config)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to set config", "Unable to set config. "+err.Error())
+		return
+	}
+
+	err = r.client.ProcessRestartIfDeployed(ctx, plan.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *appConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan appConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state appConfigResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AppName.ValueString() != state.AppName.ValueString() {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "App name can't be changed", "App name can't be changed")
+		return
+	}
+	appName := plan.AppName.ValueString()
+
+	restartRequired := false
+
+	var namesToUnset []string
+	for stateName := range state.Config {
+		if _, found := plan.Config[stateName]; !found {
+			namesToUnset = append(namesToUnset, stateName)
+		}
+	}
+	if len(namesToUnset) != 0 {
+		err := r.client.ConfigUnset(ctx, appName, namesToUnset)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to unset config", "Unable to unset config. "+err.Error())
+			return
+		}
+		restartRequired = true
+	}
+
+	configToSet := make(map[string]string)
+	for k, v := range plan.Config {
+		if !state.Config[k].Equal(v) {
+			configToSet[k] = v.ValueString()
+		}
+	}
+	if len(configToSet) != 0 {
+		err := r.client.ConfigSet(ctx, appName, configToSet)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to set config", "Unable to set config. "+err.Error())
+			return
+		}
+		restartRequired = true
+	}
+
+	if restartRequired {
+		err := r.client.ProcessRestartIfDeployed(ctx, appName)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *appConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state appConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists || len(state.Config) == 0 {
+		return
+	}
+
+	var names []string
+	for k := range state.Config {
+		names = append(names, k)
+	}
+	err = r.client.ConfigUnset(ctx, state.AppName.ValueString(), names)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to unset config", "Unable to unset config. "+err.Error())
+		return
+	}
+
+	err = r.client.ProcessRestartIfDeployed(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+		return
+	}
+}
+
+func (r *appConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to app_name attribute
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, appImportedPrivateKey, []byte("true"))...)
+}
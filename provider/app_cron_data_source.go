@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ datasource.DataSource              = &appCronDataSource{}
+	_ datasource.DataSourceWithConfigure = &appCronDataSource{}
+)
+
+func NewAppCronDataSource() datasource.DataSource {
+	return &appCronDataSource{}
+}
+
+type appCronDataSource struct {
+	client *dokkuclient.Client
+}
+
+type appCronDataSourceModel struct {
+	AppName types.String    `tfsdk:"app_name"`
+	Tasks   []cronTaskModel `tfsdk:"tasks"`
+}
+
+type cronTaskModel struct {
+	Id       types.String `tfsdk:"id"`
+	Schedule types.String `tfsdk:"schedule"`
+	Command  types.String `tfsdk:"command"`
+}
+
+// Metadata returns the data source type name.
+func (d *appCronDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_cron"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *appCronDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	d.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the data source.
+func (d *appCronDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Scheduled cron tasks of app",
+			"",
+			"Tasks are defined in app.json of deployed app, so list is empty until app is deployed.",
+			"Task could be run on demand using cron_id attribute of dokku_run resource.",
+			"https://dokku.com/docs/processes/scheduled-cron-tasks/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of application",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "invalid app_name"),
+				},
+			},
+			"tasks": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Cron tasks of app",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Task ID",
+						},
+						"schedule": schema.StringAttribute{
+							Computed:    true,
+							Description: "Cron schedule, i.e. \"@daily\" or \"5 * * * *\"",
+						},
+						"command": schema.StringAttribute{
+							Computed:    true,
+							Description: "Command to run",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *appCronDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data appCronDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tasks, err := d.client.CronList(ctx, data.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to list cron tasks", "Unable to list cron tasks. "+err.Error())
+		return
+	}
+
+	data.Tasks = make([]cronTaskModel, len(tasks))
+	for i, task := range tasks {
+		data.Tasks[i] = cronTaskModel{
+			Id:       basetypes.NewStringValue(task.ID),
+			Schedule: basetypes.NewStringValue(task.Schedule),
+			Command:  basetypes.NewStringValue(task.Command),
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
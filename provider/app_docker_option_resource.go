@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource                = &appDockerOptionResource{}
+	_ resource.ResourceWithConfigure   = &appDockerOptionResource{}
+	_ resource.ResourceWithImportState = &appDockerOptionResource{}
+)
+
+func NewAppDockerOptionResource() resource.Resource {
+	return &appDockerOptionResource{}
+}
+
+type appDockerOptionResource struct {
+	client *dokkuclient.Client
+}
+
+type appDockerOptionResourceModel struct {
+	AppName types.String `tfsdk:"app_name"`
+	Option  types.String `tfsdk:"option"`
+	Phase   types.Set    `tfsdk:"phase"`
+}
+
+// Metadata returns the resource type name.
+func (r *appDockerOptionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_docker_option"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *appDockerOptionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *appDockerOptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Single docker option for existing app",
+			"Should not be used together with dokku_app.docker_options attribute for the same option",
+			"https://dokku.com/docs/advanced-usage/docker-options/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of application to add docker option to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "invalid app_name"),
+				},
+			},
+			"option": schema.StringAttribute{
+				Required:    true,
+				Description: "Docker option, i.e. \"-v /var/run/docker.sock:/var/run/docker.sock\"",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"phase": schema.SetAttribute{
+				Required:    true,
+				Description: "Phase to apply docker-options to. Allowed values: build, deploy, run",
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("build", "deploy", "run")),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *appDockerOptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state appDockerOptionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check app existence
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	report, err := r.client.DockerOptionsReport(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("phase"), "Unable to get docker options", "Unable to get docker options. "+err.Error())
+		return
+	}
+	var phases []attr.Value
+	for _, phase := range dokkuclient.DockerOptionsPhases {
+		if slices.Contains(report[phase], dokkuclient.NormalizeDockerOption(state.Option.ValueString())) {
+			phases = append(phases, basetypes.NewStringValue(phase))
+		}
+	}
+	if len(phases) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Phase, diags = basetypes.NewSetValue(types.StringType, phases)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *appDockerOptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan appDockerOptionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DockerOptionAdd(ctx, plan.AppName.ValueString(), formatDockerOptionsPhases(plan.Phase), plan.Option.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("option"), "Unable to add docker option", "Unable to add docker option. "+err.Error())
+		return
+	}
+
+	err = r.client.ProcessRestartIfDeployed(ctx, plan.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *appDockerOptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan appDockerOptionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state appDockerOptionResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.Phase.Equal(plan.Phase) {
+		err := r.client.DockerOptionRemove(ctx, state.AppName.ValueString(), formatDockerOptionsPhases(state.Phase), state.Option.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("option"), "Unable to remove docker option", "Unable to remove docker option. "+err.Error())
+			return
+		}
+		err = r.client.DockerOptionAdd(ctx, plan.AppName.ValueString(), formatDockerOptionsPhases(plan.Phase), plan.Option.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("option"), "Unable to add docker option", "Unable to add docker option. "+err.Error())
+			return
+		}
+
+		err = r.client.ProcessRestartIfDeployed(ctx, plan.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *appDockerOptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state appDockerOptionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		return
+	}
+
+	err = r.client.DockerOptionRemove(ctx, state.AppName.ValueString(), formatDockerOptionsPhases(state.Phase), state.Option.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("option"), "Unable to remove docker option", "Unable to remove docker option. "+err.Error())
+		return
+	}
+
+	err = r.client.ProcessRestartIfDeployed(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+		return
+	}
+}
+
+func (r *appDockerOptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID in format app_name:phases:option, i.e. my-app:deploy,run:--init
+	parts := strings.SplitN(req.ID, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Import ID should be in format app_name:phases:option")
+		return
+	}
+	var phases []attr.Value
+	for _, phase := range strings.Split(parts[1], ",") {
+		phases = append(phases, basetypes.NewStringValue(phase))
+	}
+	phaseSet, diags := basetypes.NewSetValue(types.StringType, phases)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("phase"), phaseSet)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("option"), parts[2])...)
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource                = &appDomainsResource{}
+	_ resource.ResourceWithConfigure   = &appDomainsResource{}
+	_ resource.ResourceWithImportState = &appDomainsResource{}
+)
+
+func NewAppDomainsResource() resource.Resource {
+	return &appDomainsResource{}
+}
+
+type appDomainsResource struct {
+	client *dokkuclient.Client
+}
+
+type appDomainsResourceModel struct {
+	AppName types.String   `tfsdk:"app_name"`
+	Domains []types.String `tfsdk:"domains"`
+}
+
+// Metadata returns the resource type name.
+func (r *appDomainsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_domains"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *appDomainsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *appDomainsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Domains setup for existing app",
+			"Should not be used together with dokku_app.domains attribute",
+			"https://dokku.com/docs/configuration/domains/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of application to set domains for",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "invalid app_name"),
+				},
+			},
+			"domains": schema.SetAttribute{
+				Required:    true,
+				Description: "Domains setup for app",
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *appDomainsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state appDomainsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check app existence
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	domains, err := r.client.DomainsExport(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to get domains", "Unable to get domains. "+err.Error())
+		return
+	}
+	if len(domains) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Domains = make([]types.String, len(domains))
+	for i, domain := range domains {
+		state.Domains[i] = basetypes.NewStringValue(domain)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *appDomainsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan appDomainsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setDomains(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *appDomainsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan appDomainsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setDomains(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *appDomainsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state appDomainsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		return
+	}
+
+	err = r.client.DomainsClear(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to clear domains", "Unable to clear domains. "+err.Error())
+		return
+	}
+
+	err = r.client.DomainsDisable(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to disable domains", "Unable to disable domains. "+err.Error())
+		return
+	}
+}
+
+func (r *appDomainsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to app_name attribute
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), req.ID)...)
+}
+
+func (r *appDomainsResource) setDomains(ctx context.Context, plan appDomainsResourceModel) (diags diag.Diagnostics) {
+	var domains []string
+	for _, domain := range plan.Domains {
+		domains = append(domains, domain.ValueString())
+	}
+	err := r.client.DomainsEnable(ctx, plan.AppName.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("domains"), "Unable to enable domains", "Unable to enable domains. "+err.Error())
+		return
+	}
+
+	err = r.client.DomainsSet(ctx, plan.AppName.ValueString(), domains)
+	if err != nil {
+		diags.AddAttributeError(path.Root("domains"), "Unable to set domains", "Unable to set domains. "+err.Error())
+		return
+	}
+	return
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource                = &appNetworkResource{}
+	_ resource.ResourceWithConfigure   = &appNetworkResource{}
+	_ resource.ResourceWithImportState = &appNetworkResource{}
+)
+
+func NewAppNetworkResource() resource.Resource {
+	return &appNetworkResource{}
+}
+
+type appNetworkResource struct {
+	client *dokkuclient.Client
+}
+
+type appNetworkResourceModel struct {
+	AppName          types.String `tfsdk:"app_name"`
+	AttachPostCreate types.String `tfsdk:"attach_post_create"`
+	AttachPostDeploy types.String `tfsdk:"attach_post_deploy"`
+	InitialNetwork   types.String `tfsdk:"initial_network"`
+}
+
+// networkTypes returns values of model indexed by network type used by network:set.
+func (m appNetworkResourceModel) networkTypes() map[string]types.String {
+	return map[string]types.String{
+		"attach-post-create": m.AttachPostCreate,
+		"attach-post-deploy": m.AttachPostDeploy,
+		"initial-network":    m.InitialNetwork,
+	}
+}
+
+// Metadata returns the resource type name.
+func (r *appNetworkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_network"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *appNetworkResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *appNetworkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Network setup for existing app",
+			"Should not be used together with dokku_app.networks attribute",
+			"https://dokku.com/docs/networking/network/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of application to set networks for",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "invalid app_name"),
+				},
+			},
+			"attach_post_create": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of network to use as attach-post-create",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AtLeastOneOf(path.MatchRoot("attach_post_deploy"), path.MatchRoot("initial_network")),
+				},
+			},
+			"attach_post_deploy": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of network to use as attach-post-deploy",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"initial_network": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of network to use as initial-network",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *appNetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state appNetworkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check app existence
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	networks, err := r.client.NetworksReport(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get networks", "Unable to get networks. "+err.Error())
+		return
+	}
+	networkValue := func(name string) types.String {
+		if networks[name] == "" {
+			return basetypes.NewStringNull()
+		}
+		return basetypes.NewStringValue(networks[name])
+	}
+	state.AttachPostCreate = networkValue("attach post create")
+	state.AttachPostDeploy = networkValue("attach post deploy")
+	state.InitialNetwork = networkValue("initial network")
+	if state.AttachPostCreate.IsNull() && state.AttachPostDeploy.IsNull() && state.InitialNetwork.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *appNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan appNetworkResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setNetworks(ctx, plan, appNetworkResourceModel{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *appNetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan appNetworkResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state appNetworkResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setNetworks(ctx, plan, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *appNetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state appNetworkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setNetworks(ctx, appNetworkResourceModel{AppName: state.AppName}, state)...)
+}
+
+func (r *appNetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to app_name attribute
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), req.ID)...)
+}
+
+// setNetworks sets or unsets networks of plan that are different from state.
+func (r *appNetworkResource) setNetworks(ctx context.Context, plan appNetworkResourceModel, state appNetworkResourceModel) (diags diag.Diagnostics) {
+	stateNetworks := state.networkTypes()
+	for networkType, network := range plan.networkTypes() {
+		if network.Equal(stateNetworks[networkType]) {
+			continue
+		}
+		var err error
+		if network.IsNull() {
+			err = r.client.NetworkUnsetForApp(ctx, plan.AppName.ValueString(), networkType)
+		} else {
+			err = r.client.NetworkEnsureAndSetForApp(ctx, plan.AppName.ValueString(), networkType, network.ValueString())
+		}
+		if err != nil {
+			diags.AddError("Unable to set network", "Unable to set network "+networkType+". "+err.Error())
+			return
+		}
+	}
+	return
+}
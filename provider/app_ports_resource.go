@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource                = &appPortsResource{}
+	_ resource.ResourceWithConfigure   = &appPortsResource{}
+	_ resource.ResourceWithImportState = &appPortsResource{}
+)
+
+func NewAppPortsResource() resource.Resource {
+	return &appPortsResource{}
+}
+
+type appPortsResource struct {
+	client *dokkuclient.Client
+}
+
+type appPortsResourceModel struct {
+	AppName types.String         `tfsdk:"app_name"`
+	Ports   map[string]portModel `tfsdk:"ports"`
+}
+
+// Metadata returns the resource type name.
+func (r *appPortsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_ports"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *appPortsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *appPortsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Ports setup for existing app",
+			"Should not be used together with dokku_app.ports attribute",
+			"https://dokku.com/docs/networking/port-management/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of application to set ports for",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "invalid app_name"),
+				},
+			},
+			"ports": schema.MapNestedAttribute{
+				Required:    true,
+				Description: "Ports setup for app. Keys are host ports",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"scheme": schema.StringAttribute{
+							Required:    true,
+							Description: "Scheme to use. Allowed values: http, https",
+							Validators: []validator.String{
+								stringvalidator.OneOf("http", "https"),
+							},
+						},
+						"container_port": schema.StringAttribute{
+							Required:    true,
+							Description: "Port inside container to proxy",
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(regexp.MustCompile(`^\d+$`), "Must be integer"),
+							},
+						},
+					},
+				},
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^\d+$`), "Must be integer")),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *appPortsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state appPortsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check app existence
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	ports, err := r.client.PortsExport(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ports"), "Unable to get ports", "Unable to get ports. "+err.Error())
+		return
+	}
+	if len(ports) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Ports = make(map[string]portModel)
+	for _, p := range ports {
+		state.Ports[p.HostPort] = portModel{
+			Scheme:        basetypes.NewStringValue(p.Scheme),
+			ContainerPort: basetypes.NewStringValue(p.ContainerPort),
+		}
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *appPortsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan appPortsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setPorts(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *appPortsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan appPortsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setPorts(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *appPortsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state appPortsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		return
+	}
+
+	err = r.client.PortsClear(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ports"), "Unable to clear ports", "Unable to clear ports. "+err.Error())
+		return
+	}
+
+	err = r.client.ProxyDisable(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ports"), "Unable to disable ports", "Unable to disable ports. "+err.Error())
+		return
+	}
+}
+
+func (r *appPortsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to app_name attribute
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), req.ID)...)
+}
+
+func (r *appPortsResource) setPorts(ctx context.Context, plan appPortsResourceModel) (diags diag.Diagnostics) {
+	var ports []dokkuclient.Port
+	for hostPort, port := range plan.Ports {
+		ports = append(ports, dokkuclient.Port{
+			Scheme:        port.Scheme.ValueString(),
+			HostPort:      hostPort,
+			ContainerPort: port.ContainerPort.ValueString(),
+		})
+	}
+	err := r.client.PortsSet(ctx, plan.AppName.ValueString(), ports)
+	if err != nil {
+		diags.AddAttributeError(path.Root("ports"), "Unable to set ports", "Unable to set ports. "+err.Error())
+		return
+	}
+
+	err = r.client.ProxyEnable(ctx, plan.AppName.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("ports"), "Unable to enable ports", "Unable to enable ports. "+err.Error())
+		return
+	}
+	return
+}
@@ -2,22 +2,32 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
@@ -27,8 +37,146 @@ var (
 	_ resource.ResourceWithConfigure      = &appResource{}
 	_ resource.ResourceWithImportState    = &appResource{}
 	_ resource.ResourceWithValidateConfig = &appResource{}
+	_ resource.ResourceWithModifyPlan     = &appResource{}
 )
 
+var gitShaRegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// appImportedPrivateKey is set on import to read all app settings on next Read.
+const appImportedPrivateKey = "imported"
+
+// appUnsetNotManagedPrivateKey is set on create, update and import. States created by previous versions don't have it,
+// so storage, domains and networks are refreshed for them even if not set, as it was done before.
+const appUnsetNotManagedPrivateKey = "unset_not_managed"
+
+// appConfigWoKeysPrivateKey contains names of config_wo keys, because write-only values are not saved to state.
+const appConfigWoKeysPrivateKey = "config_wo_keys"
+
+// privateStateReader is implemented by private state of requests.
+type privateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateWriter is implemented by private state of responses.
+type privateStateWriter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getConfigWoKeys returns names of config_wo keys saved to private state.
+func getConfigWoKeys(ctx context.Context, private privateStateReader) (keys []string, diags diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, appConfigWoKeysPrivateKey)
+	if diags.HasError() || value == nil {
+		return nil, diags
+	}
+	err := json.Unmarshal(value, &keys)
+	if err != nil {
+		diags.AddError("Unable to read private state", "Unable to read private state. "+err.Error())
+	}
+	return
+}
+
+// readConfigFiles reads config_files and returns their values except keys overridden by other config attributes.
+// Result is unknown if some of used attributes is unknown.
+func readConfigFiles(ctx context.Context, config tfsdk.Config, configWo types.Map) (res types.Map, diags diag.Diagnostics) {
+	var configFiles types.List
+	diags.Append(config.GetAttribute(ctx, path.Root("config_files"), &configFiles)...)
+	var explicitConfig, sensitiveConfig types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("config"), &explicitConfig)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("sensitive_config"), &sensitiveConfig)...)
+	if diags.HasError() {
+		return res, diags
+	}
+	if configFiles.IsNull() {
+		return basetypes.NewMapNull(types.StringType), diags
+	}
+	if configFiles.IsUnknown() || explicitConfig.IsUnknown() || sensitiveConfig.IsUnknown() || configWo.IsUnknown() {
+		return basetypes.NewMapUnknown(types.StringType), diags
+	}
+
+	var filePaths []types.String
+	diags.Append(configFiles.ElementsAs(ctx, &filePaths, false)...)
+	if diags.HasError() {
+		return res, diags
+	}
+	values := make(map[string]attr.Value)
+	for i, filePath := range filePaths {
+		if filePath.IsUnknown() {
+			return basetypes.NewMapUnknown(types.StringType), diags
+		}
+		fileValues, err := readDotenvFile(filePath.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("config_files").AtListIndex(i), "Unable to read config file", "Unable to read config file. "+err.Error())
+			continue
+		}
+		for name, value := range fileValues {
+			values[name] = basetypes.NewStringValue(value)
+		}
+	}
+	for _, overridden := range []types.Map{explicitConfig, sensitiveConfig, configWo} {
+		for name := range overridden.Elements() {
+			delete(values, name)
+		}
+	}
+	if diags.HasError() {
+		return res, diags
+	}
+	return basetypes.NewMapValue(types.StringType, values)
+}
+
+// setConfigWoKeys saves names of config_wo keys to private state.
+func setConfigWoKeys(ctx context.Context, private privateStateWriter, configWo map[string]types.String) diag.Diagnostics {
+	if len(configWo) == 0 {
+		return private.SetKey(ctx, appConfigWoKeysPrivateKey, nil)
+	}
+	keys := make([]string, 0, len(configWo))
+	for key := range configWo {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	value, err := json.Marshal(keys)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to save private state", "Unable to save private state. "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, appConfigWoKeysPrivateKey, value)
+}
+
+// configWoHash returns hash of write-only config. It is null for empty config and unknown if some value is unknown.
+func configWoHash(config map[string]types.String) types.String {
+	if len(config) == 0 {
+		return basetypes.NewStringNull()
+	}
+	keys := make([]string, 0, len(config))
+	for key, value := range config {
+		if value.IsUnknown() {
+			return basetypes.NewStringUnknown()
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s\x00%s\x00", key, config[key].ValueString())
+	}
+	return basetypes.NewStringValue(hex.EncodeToString(hash.Sum(nil)))
+}
+
+// configValues returns all config values set in model except write-only ones.
+func (m appResourceModel) configValues() map[string]types.String {
+	res := make(map[string]types.String)
+	for name, value := range m.ConfigFromFiles {
+		res[name] = value
+	}
+	for name, value := range m.Config {
+		res[name] = value
+	}
+	for name, value := range m.SensitiveConfig {
+		res[name] = value
+	}
+	return res
+}
+
 func NewAppResource() resource.Resource {
 	return &appResource{}
 }
@@ -38,16 +186,38 @@ type appResource struct {
 }
 
 type appResourceModel struct {
-	AppName       types.String                 `tfsdk:"app_name"`
-	Config        map[string]types.String      `tfsdk:"config"`
-	Storage       map[string]storageModel      `tfsdk:"storage"`
-	Checks        *checkModel                  `tfsdk:"checks"`
-	Ports         map[string]portModel         `tfsdk:"ports"`
-	ProxyPorts    map[string]portModel         `tfsdk:"proxy_ports"`
-	Domains       []types.String               `tfsdk:"domains"`
-	DockerOptions map[string]dockerOptionModel `tfsdk:"docker_options"`
-	Networks      *networkModel                `tfsdk:"networks"`
-	Deploy        *deployModel                 `tfsdk:"deploy"`
+	AppName            types.String                 `tfsdk:"app_name"`
+	Config             map[string]types.String      `tfsdk:"config"`
+	ConfigMode         types.String                 `tfsdk:"config_mode"`
+	SensitiveConfig    map[string]types.String      `tfsdk:"sensitive_config"`
+	ConfigWo           map[string]types.String      `tfsdk:"config_wo"`
+	ConfigWoHash       types.String                 `tfsdk:"config_wo_hash"`
+	ConfigFiles        []types.String               `tfsdk:"config_files"`
+	ConfigFromFiles    map[string]types.String      `tfsdk:"config_from_files"`
+	Storage            map[string]storageModel      `tfsdk:"storage"`
+	Checks             *checkModel                  `tfsdk:"checks"`
+	Ports              map[string]portModel         `tfsdk:"ports"`
+	ProxyPorts         map[string]portModel         `tfsdk:"proxy_ports"`
+	Domains            []types.String               `tfsdk:"domains"`
+	DockerOptions      map[string]dockerOptionModel `tfsdk:"docker_options"`
+	DockerOptionsMode  types.String                 `tfsdk:"docker_options_mode"`
+	Networks           *networkModel                `tfsdk:"networks"`
+	Processes          map[string]types.Int64       `tfsdk:"processes"`
+	Resources          map[string]resourcesModel    `tfsdk:"resources"`
+	RestartPolicy      types.String                 `tfsdk:"restart_policy"`
+	StopTimeoutSeconds types.Int64                  `tfsdk:"stop_timeout_seconds"`
+	ProcfilePath       types.String                 `tfsdk:"procfile_path"`
+	AppJsonPath        types.String                 `tfsdk:"app_json_path"`
+	Locked             types.Bool                   `tfsdk:"locked"`
+	RenameInPlace      types.Bool                   `tfsdk:"rename_in_place"`
+	RollbackOnFailure  types.Bool                   `tfsdk:"rollback_on_failure"`
+	Build              *buildModel                  `tfsdk:"build"`
+	Buildpacks         []types.String               `tfsdk:"buildpacks"`
+	BuildpackStack     types.String                 `tfsdk:"buildpack_stack"`
+	Deploy             *deployModel                 `tfsdk:"deploy"`
+	DeployedImage      types.String                 `tfsdk:"deployed_image"`
+	DeployedGitSha     types.String                 `tfsdk:"deployed_git_sha"`
+	DeployedAt         types.String                 `tfsdk:"deployed_at"`
 }
 
 type storageModel struct {
@@ -56,7 +226,50 @@ type storageModel struct {
 }
 
 type checkModel struct {
-	Status types.String `tfsdk:"status"`
+	Status       types.String `tfsdk:"status"`
+	Disabled     types.Set    `tfsdk:"disabled"`
+	Skipped      types.Set    `tfsdk:"skipped"`
+	WaitToRetire types.Int64  `tfsdk:"wait_to_retire"`
+	Attempts     types.Int64  `tfsdk:"attempts"`
+	Timeout      types.Int64  `tfsdk:"timeout"`
+	Wait         types.Int64  `tfsdk:"wait"`
+}
+
+// checksConfigVars are config vars used by dokku for checks settings that are not available via checks:set.
+var checksConfigVars = []string{"DOKKU_CHECKS_ATTEMPTS", "DOKKU_CHECKS_TIMEOUT", "DOKKU_CHECKS_WAIT"}
+
+func (m *checkModel) status() string {
+	if m == nil || m.Status.IsNull() {
+		return "enabled"
+	}
+	return m.Status.ValueString()
+}
+
+func (m *checkModel) processTypes(kind string) (res []string) {
+	if m == nil {
+		return nil
+	}
+	value := m.Disabled
+	if kind == "skipped" {
+		value = m.Skipped
+	}
+	res = formatStringSet(value)
+	slices.Sort(res)
+	return
+}
+
+// configValues returns values of checksConfigVars. Empty string means that value is not set.
+func (m *checkModel) configValues() []string {
+	res := make([]string, len(checksConfigVars))
+	if m == nil {
+		return res
+	}
+	for i, value := range []types.Int64{m.Attempts, m.Timeout, m.Wait} {
+		if !value.IsNull() {
+			res[i] = strconv.FormatInt(value.ValueInt64(), 10)
+		}
+	}
+	return res
 }
 
 type portModel struct {
@@ -74,16 +287,91 @@ type networkModel struct {
 	InitialNetwork   types.String `tfsdk:"initial_network"`
 }
 
+type resourcesModel struct {
+	Limit   *resourceValuesModel `tfsdk:"limit"`
+	Reserve *resourceValuesModel `tfsdk:"reserve"`
+}
+
+type resourceValuesModel struct {
+	Cpu        types.String `tfsdk:"cpu"`
+	Memory     types.String `tfsdk:"memory"`
+	MemorySwap types.String `tfsdk:"memory_swap"`
+	NvidiaGpu  types.String `tfsdk:"nvidia_gpu"`
+}
+
+func (m *resourceValuesModel) values() dokkuclient.ResourceValues {
+	if m == nil {
+		return dokkuclient.ResourceValues{}
+	}
+	return dokkuclient.ResourceValues{
+		Cpu:        m.Cpu.ValueString(),
+		Memory:     m.Memory.ValueString(),
+		MemorySwap: m.MemorySwap.ValueString(),
+		NvidiaGpu:  m.NvidiaGpu.ValueString(),
+	}
+}
+
+// newResourceValuesModel returns nil for empty values, except when they are known from state.
+func newResourceValuesModel(values dokkuclient.ResourceValues, known bool) *resourceValuesModel {
+	if values.IsEmpty() && !known {
+		return nil
+	}
+	value := func(v string) types.String {
+		if v == "" {
+			return basetypes.NewStringNull()
+		}
+		return basetypes.NewStringValue(v)
+	}
+	return &resourceValuesModel{
+		Cpu:        value(values.Cpu),
+		Memory:     value(values.Memory),
+		MemorySwap: value(values.MemorySwap),
+		NvidiaGpu:  value(values.NvidiaGpu),
+	}
+}
+
+type buildModel struct {
+	Builder            types.String            `tfsdk:"builder"`
+	DockerfilePath     types.String            `tfsdk:"dockerfile_path"`
+	BuildDir           types.String            `tfsdk:"build_dir"`
+	NixpacksTomlPath   types.String            `tfsdk:"nixpacks_toml_path"`
+	BuildArgs          map[string]types.String `tfsdk:"build_args"`
+	SensitiveBuildArgs map[string]types.String `tfsdk:"sensitive_build_args"`
+}
+
+type buildArg struct {
+	Value     string
+	Sensitive bool
+}
+
+func (m *buildModel) buildArgs() map[string]buildArg {
+	res := make(map[string]buildArg)
+	if m == nil {
+		return res
+	}
+	for name, value := range m.BuildArgs {
+		res[name] = buildArg{Value: value.ValueString()}
+	}
+	for name, value := range m.SensitiveBuildArgs {
+		res[name] = buildArg{Value: value.ValueString(), Sensitive: true}
+	}
+	return res
+}
+
 type deployModel struct {
-	Type             types.String `tfsdk:"type"`
-	Login            types.String `tfsdk:"login"`
-	Password         types.String `tfsdk:"password"`
-	DockerImage      types.String `tfsdk:"docker_image"`
-	AllowRebuild     types.Bool   `tfsdk:"allow_rebuild"`
-	GitRepository    types.String `tfsdk:"git_repository"`
-	GitRepositoryRef types.String `tfsdk:"git_repository_ref"`
-	ArchiveType      types.String `tfsdk:"archive_type"`
-	ArchiveUrl       types.String `tfsdk:"archive_url"`
+	Type                   types.String `tfsdk:"type"`
+	Login                  types.String `tfsdk:"login"`
+	Password               types.String `tfsdk:"password"`
+	DockerImage            types.String `tfsdk:"docker_image"`
+	AllowRebuild           types.Bool   `tfsdk:"allow_rebuild"`
+	GitRepository          types.String `tfsdk:"git_repository"`
+	GitRepositoryRef       types.String `tfsdk:"git_repository_ref"`
+	ArchiveType            types.String `tfsdk:"archive_type"`
+	ArchiveUrl             types.String `tfsdk:"archive_url"`
+	DockerImageArchivePath types.String `tfsdk:"docker_image_archive_path"`
+	DockerImageArchiveHash types.String `tfsdk:"docker_image_archive_hash"`
+	LocalSourcePath        types.String `tfsdk:"local_source_path"`
+	LocalSourceHash        types.String `tfsdk:"local_source_hash"`
 }
 
 // Metadata returns the resource type name.
@@ -106,14 +394,22 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 	resp.Schema = schema.Schema{
 		Description: strings.Join([]string{
 			"dokku app",
+			"",
+			"On import all settings are read from server: config (except keys set by dokku itself and by service links), storage, checks, ports, domains, networks, docker options, processes, resources, build settings and docker image app is deployed from.",
+			"Settings that are not set in configuration are left unmanaged after import, so they could be managed by standalone resources (i.e. dokku_app_domains).",
+			"Storage, ports, domains and networks that are not set in configuration are not changed and not refreshed. Apps created by previous versions of provider keep refreshing storage, domains and networks until next apply.",
 			"https://dokku.com/docs/deployment/application-management/",
 		}, "\n  "),
 		Attributes: map[string]schema.Attribute{
 			"app_name": schema.StringAttribute{
 				Required:    true,
-				Description: "Name of application to manage",
+				Description: "Name of application to manage. App is recreated on change unless rename_in_place is set",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						var renameInPlace types.Bool
+						resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rename_in_place"), &renameInPlace)...)
+						resp.RequiresReplace = !renameInPlace.ValueBool()
+					}, "App is recreated on change unless rename_in_place is set", "App is recreated on change unless rename_in_place is set"),
 				},
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "invalid app_name"),
@@ -121,16 +417,62 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			},
 			"config": schema.MapAttribute{
 				Optional:    true,
-				Description: "Config (env vars) for app",
+				Description: "Config (env vars) for app. In additive config_mode only keys set here are managed, so other keys can be managed using dokku_app_config resource",
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`), "invalid name")),
+				},
+			},
+			"sensitive_config": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Config (env vars) for app that will not be displayed in plan output, i.e. passwords and API keys. Managed the same way as config",
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`), "invalid name")),
+				},
+			},
+			"config_wo": schema.MapAttribute{
+				Optional:    true,
+				WriteOnly:   true,
+				Description: "Write-only config (env vars) for app. Values are never saved to state, changes are detected using config_wo_hash. Requires Terraform 1.11 or later",
 				ElementType: types.StringType,
 				Validators: []validator.Map{
 					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`), "invalid name")),
-					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"config_wo_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA256 hash of config_wo. Values changed outside of terraform are detected too",
+			},
+			"config_files": schema.ListAttribute{
+				Optional:    true,
+				Description: "Local files in .env format to read config (env vars) from, i.e. [\".env.production\"]. Lines could be prefixed with export, values could be quoted and multiline. Values from later files override earlier ones, values set in config, sensitive_config and config_wo override values from files",
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"config_from_files": schema.MapAttribute{
+				Computed:    true,
+				Description: "Config (env vars) read from config_files, except keys overridden by other config attributes",
+				ElementType: types.StringType,
+			},
+			"config_mode": schema.StringAttribute{
+				Optional: true,
+				Description: strings.Join([]string{
+					"Mode of config management. Allowed values: additive, authoritative. Default: additive",
+					"In additive mode only keys set in config are managed.",
+					"In authoritative mode all other keys (except DOKKU_* keys and GIT_REV, that are set by dokku itself, and service urls set by service links, i.e. DATABASE_URL) are removed, so it should not be used together with dokku_app_config resource.",
+				}, "\n  "),
+				Validators: []validator.String{
+					stringvalidator.OneOf("additive", "authoritative"),
 				},
 			},
 			"storage": schema.MapNestedAttribute{
 				Optional:    true,
-				Description: "Persistent storage setup for app. Keys are storage names or absolute paths to host directories",
+				Description: "Persistent storage setup for app. Keys are storage names or absolute paths to host directories. Should not be set if dokku_app_storage_mount resource is used for app",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"local_directory": schema.StringAttribute{
@@ -166,17 +508,63 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Description: "Checks setup for app",
 				Attributes: map[string]schema.Attribute{
 					"status": schema.StringAttribute{
-						Required:    true,
-						Description: "Checks status. Default: enabled",
+						Optional:    true,
+						Description: "Checks status for all process types. Default: enabled",
 						Validators: []validator.String{
 							stringvalidator.OneOf("enabled", "disabled", "skipped"),
 						},
 					},
+					"disabled": schema.SetAttribute{
+						Optional:    true,
+						Description: "Process types to disable checks for. Can be set only if status is enabled",
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z0-9_-]+$`), "invalid process type")),
+						},
+					},
+					"skipped": schema.SetAttribute{
+						Optional:    true,
+						Description: "Process types to skip checks for. Can be set only if status is enabled",
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z0-9_-]+$`), "invalid process type")),
+						},
+					},
+					"wait_to_retire": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Time to wait (in seconds) before old containers are retired. Default: 60",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"attempts": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Number of attempts of each check. Set using DOKKU_CHECKS_ATTEMPTS config var. Default: 5",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"timeout": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Timeout (in seconds) of each check attempt. Set using DOKKU_CHECKS_TIMEOUT config var. Default: 5",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"wait": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Time to wait (in seconds) before first check attempt. Set using DOKKU_CHECKS_WAIT config var. Default: 5",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
 				},
 			},
 			"ports": schema.MapNestedAttribute{
 				Optional:    true,
-				Description: "Ports setup for app. Keys are host ports",
+				Description: "Ports setup for app. Keys are host ports. Should not be set if dokku_app_ports resource is used for app. Empty map clears ports and disables proxy",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"scheme": schema.StringAttribute{
@@ -226,7 +614,7 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			},
 			"domains": schema.SetAttribute{
 				Optional:    true,
-				Description: "Domains setup for app",
+				Description: "Domains setup for app. Should not be set if dokku_app_domains resource is used for app. Empty set clears domains and disables domains support",
 				ElementType: types.StringType,
 				Validators: []validator.Set{
 					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
@@ -234,7 +622,7 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			},
 			"docker_options": schema.MapNestedAttribute{
 				Optional:    true,
-				Description: "Docker options for app. Keys are options",
+				Description: "Docker options for app. Keys are options. Only options set here are managed, so other options can be managed using dokku_app_docker_option resource",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"phase": schema.SetAttribute{
@@ -251,9 +639,20 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
 				},
 			},
+			"docker_options_mode": schema.StringAttribute{
+				Optional: true,
+				Description: strings.Join([]string{
+					"Mode of docker_options management. Allowed values: additive, authoritative. Default: additive",
+					"In additive mode only options set in docker_options are managed.",
+					"In authoritative mode all other options (except --restart and --build-arg, that are managed by restart_policy and build attributes) are removed, so it should not be used together with dokku_app_docker_option resource.",
+				}, "\n  "),
+				Validators: []validator.String{
+					stringvalidator.OneOf("additive", "authoritative"),
+				},
+			},
 			"networks": schema.SingleNestedAttribute{
 				Optional:    true,
-				Description: "Network setup for app",
+				Description: "Network setup for app. Should not be set if dokku_app_network resource is used for app",
 				Attributes: map[string]schema.Attribute{
 					"attach_post_create": schema.StringAttribute{
 						Optional:    true,
@@ -278,15 +677,155 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					},
 				},
 			},
+			"processes": schema.MapAttribute{
+				Optional:    true,
+				Description: "Count of containers to run for each process type, i.e. { web = 2, worker = 1 }. Only process types set here are managed. Applied with ps:scale --skip-deploy and read back from ps:scale, because ps:report lists only running containers",
+				ElementType: types.Int64Type,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`), "invalid process type")),
+					mapvalidator.ValueInt64sAre(int64validator.AtLeast(0)),
+				},
+			},
+			"restart_policy": schema.StringAttribute{
+				Optional:    true,
+				Description: "Restart policy for app containers. Allowed values: no, always, unless-stopped, on-failure, on-failure:N. Default: on-failure:10",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(no|always|unless-stopped|on-failure(:\d+)?)$`), "invalid restart policy"),
+				},
+			},
+			"stop_timeout_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Timeout to wait for containers to stop gracefully before killing them. Default: 30",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"rename_in_place": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Rename app using apps:rename on app_name change instead of destroying and creating new app, so config, storage mounts and deployed image are kept by dokku. Storage directories and service links are not moved: storage keeps its host directory, dokku_*_link resources referencing app have to be removed from state and imported with new app name. Default: false",
+			},
+			"rollback_on_failure": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Revert changes already applied to app if update fails: config, storage mounts, checks, ports, domains, docker options, networks, processes and other settings are set back, previous image is redeployed if new revision was deployed (or app is restarted with previous settings) and renamed app is renamed back. Reverted changes are reported in warning. Content uploaded from storage local_directory is not reverted, so files added or changed by failed update are kept. Not used on create: new app is destroyed if creation fails regardless of this attribute. Default: false",
+			},
+			"locked": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Lock app for deploys, i.e. to prevent git pushes. App is unlocked while terraform applies changes to it and locked back after that. Default: false",
+			},
+			"procfile_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to Procfile relative to root of app source, i.e. \"services/api/Procfile\". Applied on next deploy. Default: Procfile",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"app_json_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to app.json relative to root of app source, i.e. \"services/api/app.json\". Applied on next deploy. Default: app.json",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"resources": schema.MapNestedAttribute{
+				Optional:    true,
+				Description: "Resource limits and reservations for app. Keys are process types, use \"_default_\" to set values for all process types",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"limit": schema.SingleNestedAttribute{
+							Optional:    true,
+							Description: "Resource limits",
+							Attributes:  resourceValuesAttributes("limit"),
+						},
+						"reserve": schema.SingleNestedAttribute{
+							Optional:    true,
+							Description: "Resource reservations",
+							Attributes:  resourceValuesAttributes("reservation"),
+						},
+					},
+				},
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9_-]*|_default_)$`), "invalid process type")),
+				},
+			},
+			"build": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Build setup for app. https://dokku.com/docs/deployment/builders/builder-management/",
+				Attributes: map[string]schema.Attribute{
+					"builder": schema.StringAttribute{
+						Optional:    true,
+						Description: "Builder to use. Allowed values: herokuish, dockerfile, pack, nixpacks, lambda, null. Default: detected automatically",
+						Validators: []validator.String{
+							stringvalidator.OneOf("herokuish", "dockerfile", "pack", "nixpacks", "lambda", "null"),
+						},
+					},
+					"dockerfile_path": schema.StringAttribute{
+						Optional:    true,
+						Description: "Path to Dockerfile used by dockerfile builder. Default: Dockerfile",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty and contain spaces"),
+						},
+					},
+					"build_dir": schema.StringAttribute{
+						Optional:    true,
+						Description: "Directory of repository to build app from, i.e. for monorepo. Default: root of repository",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty and contain spaces"),
+						},
+					},
+					"nixpacks_toml_path": schema.StringAttribute{
+						Optional:    true,
+						Description: "Path to nixpacks.toml used by nixpacks builder. Default: nixpacks.toml",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty and contain spaces"),
+						},
+					},
+					"build_args": schema.MapAttribute{
+						Optional:    true,
+						Description: "Build args passed to docker build using \"--build-arg\" docker option of build phase. Values must not contain spaces",
+						ElementType: types.StringType,
+						Validators: []validator.Map{
+							mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`), "invalid name")),
+							mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^\S*$`), "must not contain spaces")),
+						},
+					},
+					"sensitive_build_args": schema.MapAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Same as build_args, but values are not displayed in plan output. Should not contain the same keys as build_args",
+						ElementType: types.StringType,
+						Validators: []validator.Map{
+							mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`), "invalid name")),
+							mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^\S*$`), "must not contain spaces")),
+						},
+					},
+				},
+			},
+			"buildpacks": schema.ListAttribute{
+				Optional:    true,
+				Description: "Ordered list of buildpacks to use. https://dokku.com/docs/deployment/builders/herokuish-buildpacks/#specifying-a-custom-buildpack",
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty and contain spaces")),
+				},
+			},
+			"buildpack_stack": schema.StringAttribute{
+				Optional:    true,
+				Description: "Stack image to build app with using buildpacks, i.e. \"gliderlabs/herokuish:latest-22\". Default: set by builder",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty and contain spaces"),
+				},
+			},
 			"deploy": schema.SingleNestedAttribute{
 				Optional:    true,
 				Description: "Deploy setup for app",
 				Attributes: map[string]schema.Attribute{
 					"type": schema.StringAttribute{
 						Required:    true,
-						Description: "Type of deploy to use. Allowed values: archive, docker_image, git_repository",
+						Description: "Type of deploy to use. Allowed values: archive, docker_image, docker_image_archive, git_repository, local_source",
 						Validators: []validator.String{
-							stringvalidator.OneOf("archive", "docker_image", "git_repository"),
+							stringvalidator.OneOf("archive", "docker_image", "docker_image_archive", "git_repository", "local_source"),
 						},
 					},
 					"login": schema.StringAttribute{
@@ -308,7 +847,7 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					},
 					"docker_image": schema.StringAttribute{
 						Optional:    true,
-						Description: "Docker image to deploy from. If login and password is provided then it will be used to sign in to docker registry.",
+						Description: "Docker image to deploy from. If login and password is provided then it will be used to sign in to docker registry. For docker_image_archive type it is name of image inside archive",
 						Validators: []validator.String{
 							stringvalidator.LengthAtLeast(1),
 						},
@@ -317,6 +856,17 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 						Optional:    true,
 						Description: "Allow to run ps:rebuild for app if same docker_image provided second time",
 					},
+					"docker_image_archive_path": schema.StringAttribute{
+						Optional:    true,
+						Description: "Path to local archive created by \"docker save\". It is streamed to host over ssh and loaded using git:load-image, so docker registry is not required. App is redeployed if content of archive is changed, even if docker_image is the same (see docker_image_archive_hash)",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"docker_image_archive_hash": schema.StringAttribute{
+						Computed:    true,
+						Description: "SHA256 hash of docker image archive",
+					},
 					"git_repository": schema.StringAttribute{
 						Optional:    true,
 						Description: "Git repository to deploy from. If login and password is provided then it will be used to sign in to repository.",
@@ -340,16 +890,85 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					},
 					"archive_type": schema.StringAttribute{
 						Optional:    true,
-						Description: "Type of archive to deploy. Allowed values: tar, tar.gz, zip", // https://github.com/dokku/dokku/blob/master/plugins/git/git-from-archive#L25
+						Description: "Type of archive to deploy. Allowed values: tar, tar.gz, zip. For local_source type it is used only if local_source_path is archive", // https://github.com/dokku/dokku/blob/master/plugins/git/git-from-archive#L25
 						Validators: []validator.String{
 							stringvalidator.OneOf("tar", "tar.gz", "zip"),
 						},
 					},
+					"local_source_path": schema.StringAttribute{
+						Optional: true,
+						Description: strings.Join([]string{
+							"Path to local directory or archive to deploy from. It is streamed to host over ssh and deployed using git:from-archive, so app is built on host.",
+							"Directory is packed to tar archive honouring .dockerignore file. App is redeployed only if content of local source is changed (see local_source_hash).",
+						}, "\n  "),
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"local_source_hash": schema.StringAttribute{
+						Computed:    true,
+						Description: "SHA256 hash of local source content",
+					},
+				},
+			},
+			"deployed_image": schema.StringAttribute{
+				Computed:    true,
+				Description: "Docker image app is deployed from. If it differs from deploy.docker_image then app will be redeployed",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deployed_git_sha": schema.StringAttribute{
+				Computed:    true,
+				Description: "Git sha of deployed revision. If deploy.git_repository_ref is full sha and it differs from deployed one then app will be redeployed",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deployed_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time of last deploy",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 		},
 	}
 }
+
+func resourceValuesAttributes(kind string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"cpu": schema.StringAttribute{
+			Optional:    true,
+			Description: "CPU " + kind + ", i.e. \"0.5\"",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty and contain spaces"),
+			},
+		},
+		"memory": schema.StringAttribute{
+			Optional:    true,
+			Description: "Memory " + kind + ", i.e. \"512m\". Megabytes are used if unit is not specified",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty and contain spaces"),
+			},
+		},
+		"memory_swap": schema.StringAttribute{
+			Optional:    true,
+			Description: "Memory swap " + kind + ", i.e. \"1g\"",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty and contain spaces"),
+			},
+		},
+		"nvidia_gpu": schema.StringAttribute{
+			Optional:    true,
+			Description: "Nvidia GPU " + kind + ", i.e. \"1\"",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^\S+$`), "must not be empty and contain spaces"),
+			},
+		},
+	}
+}
+
 func (r *appResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data appResourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -357,6 +976,37 @@ func (r *appResource) ValidateConfig(ctx context.Context, req resource.ValidateC
 		return
 	}
 
+	for name := range data.SensitiveConfig {
+		if _, ok := data.Config[name]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("sensitive_config").AtMapKey(name), "Config key is set twice", "Config key is set in both config and sensitive_config")
+		}
+	}
+	for name := range data.ConfigWo {
+		if _, ok := data.Config[name]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("config_wo").AtMapKey(name), "Config key is set twice", "Config key is set in both config and config_wo")
+		}
+		if _, ok := data.SensitiveConfig[name]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("config_wo").AtMapKey(name), "Config key is set twice", "Config key is set in both sensitive_config and config_wo")
+		}
+	}
+
+	if data.Build != nil {
+		for name := range data.Build.SensitiveBuildArgs {
+			if _, ok := data.Build.BuildArgs[name]; ok {
+				resp.Diagnostics.AddAttributeError(path.Root("build").AtName("sensitive_build_args").AtMapKey(name), "Build arg is set twice", "Build arg is set in both build_args and sensitive_build_args")
+			}
+		}
+	}
+
+	if data.Checks != nil && !data.Checks.Status.IsUnknown() && data.Checks.status() != "enabled" {
+		if !data.Checks.Disabled.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("checks").AtName("disabled"), "disabled can be set only if status is enabled", "disabled can be set only if status is enabled")
+		}
+		if !data.Checks.Skipped.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("checks").AtName("skipped"), "skipped can be set only if status is enabled", "skipped can be set only if status is enabled")
+		}
+	}
+
 	if data.Deploy != nil {
 		switch data.Deploy.Type.ValueString() {
 		case "archive":
@@ -367,27 +1017,179 @@ func (r *appResource) ValidateConfig(ctx context.Context, req resource.ValidateC
 			if data.Deploy.DockerImage.IsNull() {
 				resp.Diagnostics.AddAttributeError(path.Root("deploy").AtName("docker_image"), "docker_image must be set for type archive", "docker_image must be set for type archive")
 			}
+		case "docker_image_archive":
+			if data.Deploy.DockerImageArchivePath.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("deploy").AtName("docker_image_archive_path"), "docker_image_archive_path must be set for type docker_image_archive", "docker_image_archive_path must be set for type docker_image_archive")
+			}
+			if data.Deploy.DockerImage.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("deploy").AtName("docker_image"), "docker_image must be set for type docker_image_archive", "docker_image must be set for type docker_image_archive")
+			}
 		case "git_repository":
 			if data.Deploy.GitRepository.IsNull() {
 				resp.Diagnostics.AddAttributeError(path.Root("deploy").AtName("git_repository"), "git_repository must be set for type archive", "git_repository must be set for type archive")
 			}
+		case "local_source":
+			if data.Deploy.LocalSourcePath.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("deploy").AtName("local_source_path"), "local_source_path must be set for type local_source", "local_source_path must be set for type local_source")
+			}
 		default:
 			resp.Diagnostics.AddAttributeError(path.Root("deploy").AtName("type"), "Invalid type value", "Invalid type value")
 		}
 	}
 }
 
-// Read refreshes the Terraform state with the latest data.
-func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// Get current state
-	var state appResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+// ModifyPlan calculates hash of local source and marks deployed revision as unknown if deploy is changed.
+func (r *appResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	// Check app existence
+	var planDeploy types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("deploy"), &planDeploy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !planDeploy.IsNull() && !planDeploy.IsUnknown() {
+		var deploy deployModel
+		resp.Diagnostics.Append(planDeploy.As(ctx, &deploy, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		localSourceHash := basetypes.NewStringNull()
+		if deploy.Type.ValueString() == "local_source" {
+			if deploy.LocalSourcePath.IsUnknown() {
+				localSourceHash = basetypes.NewStringUnknown()
+			} else {
+				hash, err := dokkuclient.LocalSourceHash(deploy.LocalSourcePath.ValueString())
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(path.Root("deploy").AtName("local_source_path"), "Unable to read local source", "Unable to read local source. "+err.Error())
+					return
+				}
+				localSourceHash = basetypes.NewStringValue(hash)
+			}
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deploy").AtName("local_source_hash"), localSourceHash)...)
+
+		dockerImageArchiveHash := basetypes.NewStringNull()
+		if deploy.Type.ValueString() == "docker_image_archive" {
+			if deploy.DockerImageArchivePath.IsUnknown() {
+				dockerImageArchiveHash = basetypes.NewStringUnknown()
+			} else {
+				hash, err := dokkuclient.DockerImageArchiveHash(deploy.DockerImageArchivePath.ValueString())
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(path.Root("deploy").AtName("docker_image_archive_path"), "Unable to read docker image archive", "Unable to read docker image archive. "+err.Error())
+					return
+				}
+				dockerImageArchiveHash = basetypes.NewStringValue(hash)
+			}
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deploy").AtName("docker_image_archive_hash"), dockerImageArchiveHash)...)
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("deploy"), &planDeploy)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// write-only values are available only in config
+	var configWo types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("config_wo"), &configWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configWoHashValue := basetypes.NewStringUnknown()
+	if !configWo.IsUnknown() {
+		configWoValues := make(map[string]types.String)
+		resp.Diagnostics.Append(configWo.ElementsAs(ctx, &configWoValues, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		configWoHashValue = configWoHash(configWoValues)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("config_wo_hash"), configWoHashValue)...)
+
+	configFromFiles, diags := readConfigFiles(ctx, req.Config, configWo)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("config_from_files"), configFromFiles)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// state is read using previous mode, so keys removed on switch to authoritative mode are not shown in plan
+	var planConfigMode, stateConfigMode types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("config_mode"), &planConfigMode)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("config_mode"), &stateConfigMode)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planConfigMode.ValueString() == "authoritative" && stateConfigMode.ValueString() != "authoritative" {
+		resp.Diagnostics.Append(r.warnUnmanagedConfigKeys(ctx, req, configWo, configFromFiles)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var stateDeploy types.Object
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deploy"), &stateDeploy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !planDeploy.Equal(stateDeploy) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deployed_image"), basetypes.NewStringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deployed_git_sha"), basetypes.NewStringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deployed_at"), basetypes.NewStringUnknown())...)
+	}
+}
+
+// warnUnmanagedConfigKeys adds warning with config keys that will be removed on switch to authoritative config mode.
+func (r *appResource) warnUnmanagedConfigKeys(ctx context.Context, req resource.ModifyPlanRequest, configWo types.Map, configFromFiles types.Map) (diags diag.Diagnostics) {
+	var appName types.String
+	var planConfig, planSensitiveConfig types.Map
+	diags.Append(req.State.GetAttribute(ctx, path.Root("app_name"), &appName)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("config"), &planConfig)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("sensitive_config"), &planSensitiveConfig)...)
+	if diags.HasError() {
+		return
+	}
+
+	var managedKeys []string
+	for _, m := range []types.Map{planConfig, planSensitiveConfig, configWo, configFromFiles} {
+		// keys are not known yet, so they are checked on apply
+		if m.IsUnknown() {
+			return
+		}
+		for k := range m.Elements() {
+			managedKeys = append(managedKeys, k)
+		}
+	}
+
+	config, err := r.client.ConfigExport(ctx, appName.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("config"), "Unable to read config", "Unable to read config. "+err.Error())
+		return
+	}
+	if unmanagedKeys := unmanagedConfigKeys(config, managedKeys); len(unmanagedKeys) != 0 {
+		diags.AddAttributeWarning(path.Root("config_mode"), "Unmanaged config keys will be removed", "Config keys that are not set in configuration will be removed: "+strings.Join(unmanagedKeys, ", "))
+	}
+	return
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state appResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check app existence
 	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
@@ -398,21 +1200,50 @@ func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	// Aspects that are not set in state can be managed by standalone resources (i.e. dokku_app_domains), so they are read only on import
+	imported, diags := req.Private.GetKey(ctx, appImportedPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	readAll := imported != nil
+	unsetNotManaged, diags := req.Private.GetKey(ctx, appUnsetNotManagedPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	legacyRefresh := unsetNotManaged == nil
+
+	configWoKeys, diags := getConfigWoKeys(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authoritativeConfig := state.ConfigMode.ValueString() == "authoritative"
 	config, err := r.client.ConfigExport(ctx, state.AppName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to get config", "Unable to get config. "+err.Error())
 	} else {
 		cfg := make(map[string]basetypes.StringValue)
+		sensitiveCfg := make(map[string]basetypes.StringValue)
+		fromFilesCfg := make(map[string]basetypes.StringValue)
 		for k, v := range config {
-			found := false
-			for knownK := range state.Config {
-				if k == knownK {
-					found = true
-					break
-				}
+			if _, ok := state.SensitiveConfig[k]; ok {
+				sensitiveCfg[k] = basetypes.NewStringValue(v)
+				continue
 			}
-			// only known keys
-			if found {
+			if slices.Contains(configWoKeys, k) {
+				continue
+			}
+			if _, ok := state.ConfigFromFiles[k]; ok {
+				fromFilesCfg[k] = basetypes.NewStringValue(v)
+				continue
+			}
+			_, found := state.Config[k]
+			// only known keys, or all keys except set by dokku itself or by service links on import and in authoritative mode.
+			// unmanaged keys are added to state to be removed on update
+			if found || ((readAll || authoritativeConfig) && !isDokkuConfigKey(k) && !isLinkConfigKey(k, v)) {
 				cfg[k] = basetypes.NewStringValue(v)
 			}
 		}
@@ -421,17 +1252,44 @@ func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		} else {
 			state.Config = cfg
 		}
+		if len(sensitiveCfg) == 0 {
+			state.SensitiveConfig = nil
+		} else {
+			state.SensitiveConfig = sensitiveCfg
+		}
+		// removed values are kept in map to be set again on update
+		if state.ConfigFromFiles != nil {
+			state.ConfigFromFiles = fromFilesCfg
+		}
+
+		// hash of actual values differs from saved one if they are changed outside of terraform
+		configWo := make(map[string]types.String)
+		for _, k := range configWoKeys {
+			if v, ok := config[k]; ok {
+				configWo[k] = basetypes.NewStringValue(v)
+			}
+		}
+		state.ConfigWoHash = configWoHash(configWo)
 	}
 
 	storage, err := r.client.StorageExport(ctx, state.AppName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("storage"), "Unable to get storage", "Unable to get storage. "+err.Error())
-	} else {
+	} else if state.Storage != nil || readAll || legacyRefresh {
 		if len(storage) == 0 {
 			state.Storage = nil
 		} else {
 			stateStorage := make(map[string]storageModel)
 			for k, v := range storage {
+				// storage could be set using absolute host path (i.e. dokku_storage.host_path)
+				if _, ok := state.Storage[k]; !ok {
+					if hostPath := dokkuclient.StorageHostPath(k); hostPath != k {
+						if _, ok := state.Storage[hostPath]; ok {
+							k = hostPath
+						}
+					}
+				}
+
 				localDirectory := basetypes.NewStringNull()
 				if storageConfig, ok := state.Storage[k]; ok {
 					localDirectory = storageConfig.LocalDirectory
@@ -446,23 +1304,50 @@ func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		}
 	}
 
-	checkStatus, err := r.client.ChecksGet(ctx, state.AppName.ValueString())
+	checksReport, err := r.client.ChecksReport(ctx, state.AppName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("checks"), "Unable to get checks", "Unable to get checks. "+err.Error())
 	} else {
-		if checkStatus == "enabled" {
-			state.Checks = nil
-		} else {
-			state.Checks = &checkModel{
-				Status: basetypes.NewStringValue(checkStatus),
+		checks := &checkModel{
+			Status:       basetypes.NewStringNull(),
+			WaitToRetire: basetypes.NewInt64Null(),
+			Attempts:     basetypes.NewInt64Null(),
+			Timeout:      basetypes.NewInt64Null(),
+			Wait:         basetypes.NewInt64Null(),
+		}
+		if state.Checks != nil {
+			*checks = *state.Checks
+		}
+		// enabled status is default, so it is set only if it was set before
+		if checksReport.Status != "enabled" || !checks.Status.IsNull() {
+			checks.Status = basetypes.NewStringValue(checksReport.Status)
+		}
+		checks.Disabled, diags = stringSetValueOrNull(checksReport.Disabled)
+		resp.Diagnostics.Append(diags...)
+		checks.Skipped, diags = stringSetValueOrNull(checksReport.Skipped)
+		resp.Diagnostics.Append(diags...)
+		if !checks.WaitToRetire.IsNull() || readAll {
+			checks.WaitToRetire = int64ValueOrNull(checksReport.WaitToRetire)
+		}
+		if config != nil {
+			for i, value := range []*types.Int64{&checks.Attempts, &checks.Timeout, &checks.Wait} {
+				if !value.IsNull() || readAll {
+					*value = int64ValueOrNull(config[checksConfigVars[i]])
+				}
 			}
 		}
+
+		if state.Checks == nil && checks.Status.IsNull() && checks.Disabled.IsNull() && checks.Skipped.IsNull() &&
+			checks.WaitToRetire.IsNull() && checks.Attempts.IsNull() && checks.Timeout.IsNull() && checks.Wait.IsNull() {
+			checks = nil
+		}
+		state.Checks = checks
 	}
 
 	domains, err := r.client.DomainsExport(ctx, state.AppName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to get domains", "Unable to get domains. "+err.Error())
-	} else {
+	} else if state.Domains != nil || readAll || legacyRefresh {
 		if len(domains) == 0 {
 			state.Domains = nil
 		} else {
@@ -492,8 +1377,8 @@ func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 					break
 				}
 			}
-			// only known hostport's
-			if found {
+			// only known hostport's, or all of them on import
+			if found || readAll {
 				pp[p.HostPort] = portModel{
 					Scheme:        basetypes.NewStringValue(p.Scheme),
 					ContainerPort: basetypes.NewStringValue(p.ContainerPort),
@@ -511,12 +1396,56 @@ func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		}
 	}
 
-	// dockerOptions -- unable to read because it can be set externally
+	authoritativeDockerOptions := state.DockerOptionsMode.ValueString() == "authoritative"
+	if state.DockerOptions != nil || readAll || authoritativeDockerOptions {
+		report, err := r.client.DockerOptionsReport(ctx, state.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("docker_options"), "Unable to get docker options", "Unable to get docker options. "+err.Error())
+		} else {
+			optionPhases := make(map[string][]attr.Value)
+			for _, phase := range dokkuclient.DockerOptionsPhases {
+				for _, option := range report[phase] {
+					optionPhases[option] = append(optionPhases[option], basetypes.NewStringValue(phase))
+				}
+			}
+
+			dockerOptions := make(map[string]dockerOptionModel)
+			for option := range state.DockerOptions {
+				normalizedOption := dokkuclient.NormalizeDockerOption(option)
+				phases, ok := optionPhases[normalizedOption]
+				// removed outside of terraform
+				if !ok {
+					continue
+				}
+				delete(optionPhases, normalizedOption)
+				phaseSet, diags := basetypes.NewSetValue(types.StringType, phases)
+				resp.Diagnostics.Append(diags...)
+				dockerOptions[option] = dockerOptionModel{Phase: phaseSet}
+			}
+			// unmanaged options are added to state to be removed on update
+			if readAll || authoritativeDockerOptions {
+				for option, phases := range optionPhases {
+					// restart policy and build args are managed by restart_policy and build attributes
+					if strings.HasPrefix(option, "--restart=") || dokkuclient.IsBuildArgDockerOption(option) {
+						continue
+					}
+					phaseSet, diags := basetypes.NewSetValue(types.StringType, phases)
+					resp.Diagnostics.Append(diags...)
+					dockerOptions[option] = dockerOptionModel{Phase: phaseSet}
+				}
+			}
+			if len(dockerOptions) == 0 {
+				state.DockerOptions = nil
+			} else {
+				state.DockerOptions = dockerOptions
+			}
+		}
+	}
 
 	networks, err := r.client.NetworksReport(ctx, state.AppName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("networks"), "Unable to get networks", "Unable to get networks. "+err.Error())
-	} else {
+	} else if state.Networks != nil || readAll || legacyRefresh {
 		var attachPostCreate types.String
 		if networks["attach post create"] != "" {
 			attachPostCreate = basetypes.NewStringValue(networks["attach post create"])
@@ -546,12 +1475,179 @@ func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		}
 	}
 
-	// deploy -- unable to read
+	if state.Processes != nil || readAll {
+		scale, err := r.client.ProcessScaleGet(ctx, state.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("processes"), "Unable to get processes", "Unable to get processes. "+err.Error())
+		} else {
+			processes := make(map[string]types.Int64)
+			for processType := range state.Processes {
+				processes[processType] = basetypes.NewInt64Value(scale[processType])
+			}
+			if readAll {
+				for processType, count := range scale {
+					processes[processType] = basetypes.NewInt64Value(count)
+				}
+			}
+			if len(processes) == 0 {
+				state.Processes = nil
+			} else {
+				state.Processes = processes
+			}
+		}
+	}
+
+	if !state.RestartPolicy.IsNull() || !state.StopTimeoutSeconds.IsNull() || !state.ProcfilePath.IsNull() || readAll {
+		report, err := r.client.ProcessReport(ctx, state.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to get process settings", "Unable to get process settings. "+err.Error())
+		} else {
+			restartPolicy := report["Ps restart policy"]
+			// default restart policy is not imported
+			if restartPolicy == "" || (state.RestartPolicy.IsNull() && restartPolicy == "on-failure:10") {
+				state.RestartPolicy = basetypes.NewStringNull()
+			} else {
+				state.RestartPolicy = basetypes.NewStringValue(restartPolicy)
+			}
+
+			stopTimeoutSeconds, err := strconv.ParseInt(report["Ps stop timeout seconds"], 10, 64)
+			if err != nil {
+				state.StopTimeoutSeconds = basetypes.NewInt64Null()
+			} else {
+				state.StopTimeoutSeconds = basetypes.NewInt64Value(stopTimeoutSeconds)
+			}
+
+			state.ProcfilePath = stringValueOrNull(report["Ps procfile path"])
+		}
+	}
+
+	if !state.Locked.IsNull() || readAll {
+		locked, err := r.client.AppLocked(ctx, state.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("locked"), "Unable to check app lock", "Unable to check app lock. "+err.Error())
+		} else if locked || !state.Locked.IsNull() {
+			state.Locked = basetypes.NewBoolValue(locked)
+		}
+	}
+
+	if !state.AppJsonPath.IsNull() || readAll {
+		report, err := r.client.AppJsonReport(ctx, state.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("app_json_path"), "Unable to get app.json settings", "Unable to get app.json settings. "+err.Error())
+		} else {
+			state.AppJsonPath = stringValueOrNull(report["App json appjson path"])
+		}
+	}
+
+	if state.Resources != nil || readAll {
+		report, err := r.client.ResourcesReport(ctx, state.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("resources"), "Unable to get resources", "Unable to get resources. "+err.Error())
+		} else {
+			resources := make(map[string]resourcesModel)
+			for processType, processResources := range report {
+				stateResources, known := state.Resources[processType]
+				// only known process types
+				if !known && !readAll {
+					continue
+				}
+				m := resourcesModel{
+					Limit:   newResourceValuesModel(processResources.Limit, stateResources.Limit != nil),
+					Reserve: newResourceValuesModel(processResources.Reserve, stateResources.Reserve != nil),
+				}
+				if m.Limit != nil || m.Reserve != nil || known {
+					resources[processType] = m
+				}
+			}
+			for processType, stateResources := range state.Resources {
+				if _, ok := resources[processType]; !ok {
+					resources[processType] = resourcesModel{
+						Limit:   newResourceValuesModel(dokkuclient.ResourceValues{}, stateResources.Limit != nil),
+						Reserve: newResourceValuesModel(dokkuclient.ResourceValues{}, stateResources.Reserve != nil),
+					}
+				}
+			}
+			if len(resources) == 0 {
+				state.Resources = nil
+			} else {
+				state.Resources = resources
+			}
+		}
+	}
+
+	if state.Build != nil || readAll {
+		resp.Diagnostics.Append(r.readBuild(ctx, &state, readAll)...)
+	}
+
+	if state.Buildpacks != nil || !state.BuildpackStack.IsNull() || readAll {
+		buildpacks, stack, err := r.client.BuildpacksReport(ctx, state.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("buildpacks"), "Unable to get buildpacks", "Unable to get buildpacks. "+err.Error())
+		} else {
+			if state.Buildpacks != nil || readAll {
+				state.Buildpacks = nil
+				for _, buildpack := range buildpacks {
+					state.Buildpacks = append(state.Buildpacks, basetypes.NewStringValue(buildpack))
+				}
+			}
+			if !state.BuildpackStack.IsNull() || readAll {
+				state.BuildpackStack = stringValueOrNull(stack)
+			}
+		}
+	}
+
+	deployInfo, deployed, err := r.readDeployedRevision(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("deploy"), "Unable to get deployed revision", "Unable to get deployed revision. "+err.Error())
+	} else if state.Deploy == nil && readAll && deployed {
+		if deployInfo.SourceImage != "" {
+			state.Deploy = &deployModel{
+				Type:                   basetypes.NewStringValue("docker_image"),
+				Login:                  basetypes.NewStringNull(),
+				Password:               basetypes.NewStringNull(),
+				DockerImage:            basetypes.NewStringValue(deployInfo.SourceImage),
+				AllowRebuild:           basetypes.NewBoolNull(),
+				GitRepository:          basetypes.NewStringNull(),
+				GitRepositoryRef:       basetypes.NewStringNull(),
+				ArchiveType:            basetypes.NewStringNull(),
+				ArchiveUrl:             basetypes.NewStringNull(),
+				DockerImageArchivePath: basetypes.NewStringNull(),
+				DockerImageArchiveHash: basetypes.NewStringNull(),
+				LocalSourcePath:        basetypes.NewStringNull(),
+				LocalSourceHash:        basetypes.NewStringNull(),
+			}
+		} else {
+			// url of git repository, archive or local source is not saved by dokku
+			resp.Diagnostics.AddAttributeWarning(path.Root("deploy"), "Unable to import deploy source", "Unable to import deploy source. App is not deployed from docker image, so deploy attribute should be set manually")
+		}
+	} else if state.Deploy != nil {
+		// changed deploy will cause redeploy on update
+		if !deployed {
+			state.Deploy = nil
+		} else {
+			switch state.Deploy.Type.ValueString() {
+			case "docker_image", "docker_image_archive":
+				if deployInfo.SourceImage != "" && deployInfo.SourceImage != state.Deploy.DockerImage.ValueString() {
+					state.Deploy.DockerImage = basetypes.NewStringValue(deployInfo.SourceImage)
+				}
+			case "git_repository":
+				// only ref that is sha can be compared
+				ref := state.Deploy.GitRepositoryRef.ValueString()
+				if gitShaRegexp.MatchString(ref) && deployInfo.GitSha != "" && deployInfo.GitSha != ref {
+					state.Deploy.GitRepositoryRef = basetypes.NewStringValue(deployInfo.GitSha)
+				}
+			}
+		}
+	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if readAll {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, appImportedPrivateKey, nil)...)
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -589,16 +1685,26 @@ func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	if len(plan.Config) != 0 {
-		config := make(map[string]string)
-		for k, v := range plan.Config {
-			config[k] = v.ValueString()
-		}
+	// write-only values are available only in config
+	var configWo map[string]types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("config_wo"), &configWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config := make(map[string]string)
+	for k, v := range plan.configValues() {
+		config[k] = v.ValueString()
+	}
+	for k, v := range configWo {
+		config[k] = v.ValueString()
+	}
+	if len(config) != 0 {
 		err := r.client.ConfigSet(ctx, plan.AppName.ValueString(), config)
 		if err != nil {
 			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to set config", "Unable to set config. "+err.Error())
 		}
 	}
+	resp.Diagnostics.Append(setConfigWoKeys(ctx, resp.Private, configWo)...)
 
 	for hostPath, storage := range plan.Storage {
 		err := r.client.StorageEnsure(ctx, hostPath, storage.LocalDirectory.ValueStringPointer())
@@ -612,14 +1718,8 @@ func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, re
 		}
 	}
 
-	if plan.Checks != nil {
-		if !plan.Checks.Status.IsNull() {
-			err := r.client.ChecksSet(ctx, plan.AppName.ValueString(), plan.Checks.Status.ValueString())
-			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("checks"), "Unable to set checks", "Unable to set checks. "+err.Error())
-			}
-		}
-	}
+	_, diags = r.applyChecks(ctx, plan.AppName.ValueString(), plan.Checks, nil)
+	resp.Diagnostics.Append(diags...)
 
 	if len(plan.Ports) != 0 || len(plan.ProxyPorts) != 0 {
 		if len(plan.ProxyPorts) > 0 {
@@ -649,7 +1749,8 @@ func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, re
 		if err != nil {
 			resp.Diagnostics.AddAttributeError(path.Root("ports"), "Unable to enable ports", "Unable to enable ports. "+err.Error())
 		}
-	} else {
+	} else if plan.Ports != nil || plan.ProxyPorts != nil {
+		// ports that are not set are not managed here (i.e. by dokku_app_ports resource), empty ports disable proxy
 		err = r.client.ProxyDisable(ctx, plan.AppName.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddAttributeError(path.Root("ports"), "Unable to disable ports", "Unable to disable ports. "+err.Error())
@@ -669,7 +1770,8 @@ func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, re
 		if err != nil {
 			resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to enable domains support", "Unable to enable domains support. "+err.Error())
 		}
-	} else {
+	} else if plan.Domains != nil {
+		// domains that are not set are not managed here (i.e. by dokku_app_domains resource), empty domains disable domains support
 		err = r.client.DomainsDisable(ctx, plan.AppName.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to disable domains support", "Unable to disable domains support. "+err.Error())
@@ -704,91 +1806,772 @@ func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, re
 		}
 	}
 
-	if plan.Deploy != nil && !resp.Diagnostics.HasError() {
-		_, err := r.deploy(ctx, plan.AppName.ValueString(), *plan.Deploy)
+	if len(plan.Processes) != 0 {
+		err := r.client.ProcessScaleSet(ctx, plan.AppName.ValueString(), formatProcesses(plan.Processes))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("processes"), "Unable to scale processes", "Unable to scale processes. "+err.Error())
+		}
+	}
+
+	if !plan.RestartPolicy.IsNull() {
+		err := r.client.ProcessSet(ctx, plan.AppName.ValueString(), "restart-policy", plan.RestartPolicy.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("restart_policy"), "Unable to set restart policy", "Unable to set restart policy. "+err.Error())
+		}
+	}
+
+	if !plan.StopTimeoutSeconds.IsNull() {
+		err := r.client.ProcessSet(ctx, plan.AppName.ValueString(), "stop-timeout-seconds", plan.StopTimeoutSeconds.String())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("stop_timeout_seconds"), "Unable to set stop timeout", "Unable to set stop timeout. "+err.Error())
+		}
+	}
+
+	if !plan.ProcfilePath.IsNull() {
+		err := r.client.ProcessSet(ctx, plan.AppName.ValueString(), "procfile-path", plan.ProcfilePath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("procfile_path"), "Unable to set procfile path", "Unable to set procfile path. "+err.Error())
+		}
+	}
+
+	if !plan.AppJsonPath.IsNull() {
+		err := r.client.AppJsonSet(ctx, plan.AppName.ValueString(), "appjson-path", plan.AppJsonPath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("app_json_path"), "Unable to set app.json path", "Unable to set app.json path. "+err.Error())
+		}
+	}
+
+	for processType, resources := range plan.Resources {
+		if resources.Limit != nil {
+			err := r.client.ResourceLimitSet(ctx, plan.AppName.ValueString(), processType, resources.Limit.values())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("resources").AtMapKey(processType).AtName("limit"), "Unable to set resource limit", "Unable to set resource limit. "+err.Error())
+			}
+		}
+		if resources.Reserve != nil {
+			err := r.client.ResourceReserveSet(ctx, plan.AppName.ValueString(), processType, resources.Reserve.values())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("resources").AtMapKey(processType).AtName("reserve"), "Unable to set resource reservation", "Unable to set resource reservation. "+err.Error())
+			}
+		}
+	}
+
+	if plan.Build != nil {
+		_, diags = r.applyBuild(ctx, plan.AppName.ValueString(), plan.Build, nil)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if len(plan.Buildpacks) != 0 {
+		err := r.client.BuildpacksSet(ctx, plan.AppName.ValueString(), formatBuildpacks(plan.Buildpacks))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("buildpacks"), "Unable to set buildpacks", "Unable to set buildpacks. "+err.Error())
+		}
+	}
+
+	if !plan.BuildpackStack.IsNull() {
+		err := r.client.BuildpacksSetStack(ctx, plan.AppName.ValueString(), plan.BuildpackStack.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("buildpack_stack"), "Unable to set buildpack stack", "Unable to set buildpack stack. "+err.Error())
+		}
+	}
+
+	if plan.Deploy != nil && !resp.Diagnostics.HasError() {
+		_, err := r.deploy(ctx, plan.AppName.ValueString(), *plan.Deploy)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("deploy"), "Unable to deploy", "Unable to deploy. "+err.Error())
+		}
+	}
+
+	if plan.Locked.ValueBool() && !resp.Diagnostics.HasError() {
+		err := r.client.AppLock(ctx, plan.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("locked"), "Unable to lock app", "Unable to lock app. "+err.Error())
+		}
+	}
+
+	// rollback_on_failure steps are not applied on create: new app is destroyed as a whole, so nothing is left partially created
+	if resp.Diagnostics.HasError() {
+		err := r.client.AppDestroy(ctx, plan.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to destroy app", "Unable to destroy app. "+err.Error())
+			return
+		}
+		resp.Diagnostics.AddWarning("App is destroyed", "Creation failed, so created app is destroyed.")
+		return
+	}
+
+	_, _, err = r.readDeployedRevision(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("deploy"), "Unable to get deployed revision", "Unable to get deployed revision. "+err.Error())
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, appUnsetNotManagedPrivateKey, []byte("true"))...)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan appResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state appResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AppName.ValueString() != state.AppName.ValueString() && !plan.RenameInPlace.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "App name can't be changed", "App name can't be changed")
+		return
+	}
+	// app name is changed only after successful rename
+	appName := state.AppName.ValueString()
+
+	// local source is deployed only if it is changed, because every deploy causes rebuild
+	deployRequired := plan.Deploy != nil && (plan.Deploy.Type.ValueString() != "local_source" || state.Deploy == nil || *plan.Deploy != *state.Deploy)
+
+	// -- lock
+	// app is unlocked while changes are applied, so deploy is not blocked by own lock
+	if state.Locked.ValueBool() {
+		err := r.client.AppUnlock(ctx, appName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("locked"), "Unable to unlock app", "Unable to unlock app. "+err.Error())
+			return
+		}
+	}
+	if plan.Locked.ValueBool() {
+		// app is locked back even if some changes are failed
+		defer func() {
+			err := r.client.AppLock(ctx, appName)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("locked"), "Unable to lock app", "Unable to lock app. "+err.Error())
+			}
+		}()
+	}
+	// --
+
+	// -- rollback
+	rollbackOnFailure := plan.RollbackOnFailure.ValueBool()
+	// applied changes are reverted in reverse order
+	var rollback []appRollbackStep
+	if rollbackOnFailure {
+		// deferred after lock, so app is locked back after rollback
+		defer func() {
+			if resp.Diagnostics.HasError() {
+				resp.Diagnostics.Append(r.rollback(ctx, rollback)...)
+			}
+		}()
+	}
+	// --
+
+	// -- rename
+	if plan.AppName.ValueString() != appName {
+		// app is rebuilt on rename, so it is skipped if app will be deployed anyway or is not deployed yet
+		skipDeploy := deployRequired
+		if !skipDeploy {
+			deployed, err := r.client.ProcessIsDeployed(ctx, appName)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app deploy status", "Unable to check app deploy status. "+err.Error())
+				return
+			}
+			skipDeploy = !deployed
+		}
+		err := r.client.AppRename(ctx, appName, plan.AppName.ValueString(), skipDeploy)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to rename app", "Unable to rename app. "+err.Error())
+			return
+		}
+		// prior state is kept if later step fails, so new name is saved right away to not lose renamed app
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), plan.AppName)...)
+		if rollbackOnFailure {
+			previousName, newName := appName, plan.AppName.ValueString()
+			rollback = append(rollback, func(ctx context.Context) (string, diag.Diagnostics) {
+				var diags diag.Diagnostics
+				err := r.client.AppRename(ctx, newName, previousName, skipDeploy)
+				if err != nil {
+					diags.AddAttributeError(path.Root("app_name"), "Unable to rename app back", "Unable to rename app back. "+err.Error())
+					return "app name (renamed back to " + previousName + ")", diags
+				}
+				diags.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), previousName)...)
+				return "app name (renamed back to " + previousName + ")", diags
+			})
+		}
+		appName = plan.AppName.ValueString()
+	}
+	// --
+
+	restartRequired := false
+
+	// running app is restored after all settings are reverted, so it uses previous settings
+	var previousRevision dokkuclient.DeployInfo
+	deployAttempted, restarted := false, false
+	if rollbackOnFailure {
+		rollback = append(rollback, func(ctx context.Context) (string, diag.Diagnostics) {
+			return r.revertDeploy(ctx, appName, previousRevision, deployAttempted, restarted)
+		})
+	}
+
+	// -- config
+	// write-only values are available only in config
+	var planConfigWo map[string]types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("config_wo"), &planConfigWo)...)
+	stateConfigWoKeys, diags := getConfigWoKeys(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planConfig, stateConfig := plan.configValues(), state.configValues()
+	// actual values are saved, because sensitive and write-only ones could be changed outside of terraform
+	var configSnapshot map[string]string
+	if rollbackOnFailure {
+		var err error
+		configSnapshot, err = r.client.ConfigExport(ctx, appName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to read config", "Unable to read config. "+err.Error())
+			return
+		}
+	}
+
+	var namesToUnset []string
+	for stateName := range stateConfig {
+		if _, ok := planConfig[stateName]; !ok {
+			if _, ok := planConfigWo[stateName]; !ok {
+				namesToUnset = append(namesToUnset, stateName)
+			}
+		}
+	}
+	for _, stateName := range stateConfigWoKeys {
+		if _, ok := planConfig[stateName]; !ok {
+			if _, ok := planConfigWo[stateName]; !ok {
+				namesToUnset = append(namesToUnset, stateName)
+			}
+		}
+	}
+	// unmanaged keys are found using planned mode, because state is read using previous one
+	if plan.ConfigMode.ValueString() == "authoritative" {
+		config := configSnapshot
+		if config == nil {
+			var err error
+			config, err = r.client.ConfigExport(ctx, appName)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to read config", "Unable to read config. "+err.Error())
+				return
+			}
+		}
+		managedNames := slices.Clone(namesToUnset)
+		for name := range planConfig {
+			managedNames = append(managedNames, name)
+		}
+		for name := range planConfigWo {
+			managedNames = append(managedNames, name)
+		}
+		namesToUnset = append(namesToUnset, unmanagedConfigKeys(config, managedNames)...)
+	}
+	if len(namesToUnset) != 0 {
+		err := r.client.ConfigUnset(ctx, appName, namesToUnset)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to unset config", "Unable to unset config. "+err.Error())
+		}
+		restartRequired = true
+	}
+
+	configToSet := make(map[string]string)
+	for k, v := range planConfig {
+		if !stateConfig[k].Equal(v) {
+			configToSet[k] = v.ValueString()
+		}
+	}
+	if !plan.ConfigWoHash.Equal(state.ConfigWoHash) {
+		for k, v := range planConfigWo {
+			configToSet[k] = v.ValueString()
+		}
+	}
+	if len(configToSet) != 0 {
+		err := r.client.ConfigSet(ctx, appName, configToSet)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to set config", "Unable to set config. "+err.Error())
+		}
+		restartRequired = true
+	}
+	resp.Diagnostics.Append(setConfigWoKeys(ctx, resp.Private, planConfigWo)...)
+	if rollbackOnFailure && (len(namesToUnset) != 0 || len(configToSet) != 0) {
+		changedNames := slices.Clone(namesToUnset)
+		for name := range configToSet {
+			changedNames = append(changedNames, name)
+		}
+		slices.Sort(changedNames)
+		rollback = append(rollback, func(ctx context.Context) (string, diag.Diagnostics) {
+			diags := r.revertConfig(ctx, appName, configSnapshot, changedNames)
+			stateConfigWo := make(map[string]types.String)
+			for _, name := range stateConfigWoKeys {
+				stateConfigWo[name] = basetypes.NewStringNull()
+			}
+			diags.Append(setConfigWoKeys(ctx, resp.Private, stateConfigWo)...)
+			return "config (" + strings.Join(changedNames, ", ") + ")", diags
+		})
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	// --
+
+	// -- settings
+	if len(plan.ProxyPorts) > 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root("proxy_ports"), "proxy_ports attribute is deprecated, use ports attribute instead", "proxy_ports attribute is deprecated, use ports attribute instead")
+	}
+	settings := []struct {
+		description string
+		// restart is required to apply changes to running containers
+		restart bool
+		apply   func(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics)
+	}{
+		{"storage mounts", true, r.applyStorage},
+		{"checks", false, func(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (bool, diag.Diagnostics) {
+			return r.applyChecks(ctx, appName, plan.Checks, state.Checks)
+		}},
+		{"ports", false, r.applyPorts},
+		// TODO run letsencrypt:enable again after adding new domains
+		{"domains", false, r.applyDomains},
+		{"docker options", true, r.applyDockerOptions},
+		{"networks", false, r.applyNetworks},
+		{"process scaling", true, r.applyProcesses},
+		{"restart policy", true, r.applyRestartPolicy},
+		{"stop timeout, procfile and app.json paths", false, r.applyProcessSettings},
+		{"resource limits and reservations", true, r.applyResources},
+		{"build settings", false, func(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (bool, diag.Diagnostics) {
+			return r.applyBuild(ctx, appName, plan.Build, state.Build)
+		}},
+		{"buildpacks", false, r.applyBuildpacks},
+	}
+	for _, setting := range settings {
+		changed, diags := setting.apply(ctx, appName, &plan, &state)
+		resp.Diagnostics.Append(diags...)
+		if !changed {
+			continue
+		}
+		if setting.restart {
+			restartRequired = true
+		}
+		if rollbackOnFailure {
+			// partially applied changes are reverted too
+			rollback = append(rollback, func(ctx context.Context) (string, diag.Diagnostics) {
+				// changes are reverted by applying them from plan back to state.
+				// content uploaded from storage local_directory can't be reverted, so it is not uploaded again
+				_, diags := setting.apply(ctx, appName, withoutStorageLocalDirectories(state), withoutStorageLocalDirectories(plan))
+				return setting.description, diags
+			})
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+	// --
+
+	// -- deploy
+	if deployRequired {
+		if rollbackOnFailure {
+			var err error
+			previousRevision, err = r.deployedRevision(ctx, appName)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("deploy"), "Unable to get deployed revision", "Unable to get deployed revision. "+err.Error())
+				return
+			}
+		}
+		deployAttempted = true
+		deployModel := *plan.Deploy
+		if deployModel.Type.ValueString() == "docker_image_archive" && state.Deploy != nil && !deployModel.DockerImageArchiveHash.Equal(state.Deploy.DockerImageArchiveHash) {
+			// archive with the same docker image is not redeployed by git:load-image, so app is rebuilt from loaded image
+			deployModel.AllowRebuild = basetypes.NewBoolValue(true)
+		}
+		deployed, err := r.deploy(ctx, plan.AppName.ValueString(), deployModel)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("deploy"), "Unable to deploy", "Unable to deploy. "+err.Error())
+		}
+		if deployed {
+			restartRequired = false
+			restarted = true
+		}
+	}
+	// --
+
+	if !resp.Diagnostics.HasError() && restartRequired {
+		restarted = true
+		err := r.client.ProcessRestart(ctx, appName)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, _, err := r.readDeployedRevision(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("deploy"), "Unable to get deployed revision", "Unable to get deployed revision. "+err.Error())
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, appUnsetNotManagedPrivateKey, []byte("true"))...)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *appResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state appResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		return
+	}
+
+	if state.Locked.ValueBool() {
+		err = r.client.AppUnlock(ctx, state.AppName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("locked"), "Unable to unlock app", "Unable to unlock app. "+err.Error())
+			return
+		}
+	}
+
+	// Delete existing app
+	err = r.client.AppDestroy(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to destroy app", "Unable to destroy app. "+err.Error())
+		return
+	}
+}
+
+func (r *appResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to app_name attribute
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, appImportedPrivateKey, []byte("true"))...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, appUnsetNotManagedPrivateKey, []byte("true"))...)
+}
+
+func (r *appResource) deploy(ctx context.Context, appName string, deployModel deployModel) (deployed bool, err error) {
+	switch deployModel.Type.ValueString() {
+	case "archive":
+		err = r.client.DeployFromArchive(ctx, appName, deployModel.ArchiveType.ValueString(), deployModel.ArchiveUrl.ValueString())
+		deployed = err == nil
+	case "docker_image":
+		if !deployModel.Login.IsNull() && !deployModel.Password.IsNull() {
+			u, err := url.Parse("https://" + deployModel.DockerImage.ValueString())
+			if err != nil {
+				return false, fmt.Errorf("unable to parse url: %w", err)
+			}
+			err = r.client.RegistryLogin(ctx, u.Host, deployModel.Login.ValueString(), deployModel.Password.ValueString())
+			if err != nil {
+				return false, fmt.Errorf("unable to login to registry: %w", err)
+			}
+		}
+
+		deployed, err = r.client.DeployFromImage(ctx, appName, deployModel.DockerImage.ValueString(), deployModel.AllowRebuild.ValueBool())
+	case "docker_image_archive":
+		deployed, err = r.client.DeployFromImageArchive(ctx, appName, deployModel.DockerImageArchivePath.ValueString(), deployModel.DockerImage.ValueString(), deployModel.AllowRebuild.ValueBool())
+	case "local_source":
+		err = r.client.DeployFromLocalSource(ctx, appName, deployModel.LocalSourcePath.ValueString(), deployModel.ArchiveType.ValueString())
+		deployed = err == nil
+	case "git_repository":
+		if !deployModel.Login.IsNull() && !deployModel.Password.IsNull() {
+			u, err := url.Parse(deployModel.GitRepository.ValueString())
+			if err != nil {
+				return false, fmt.Errorf("unable to parse url: %w", err)
+			}
+			err = r.client.GitAuth(ctx, u.Host, deployModel.Login.ValueString(), deployModel.Password.ValueString())
+			if err != nil {
+				return false, fmt.Errorf("unable to login to git: %w", err)
+			}
+		}
+
+		err = r.client.DeploySyncRepository(ctx, appName, deployModel.GitRepository.ValueString(), deployModel.GitRepositoryRef.ValueString())
+		deployed = err == nil
+	default:
+		err = fmt.Errorf("Unknown deploy type %s", deployModel.Type.ValueString())
+	}
+	return
+}
+
+// readBuild sets build attribute of model. On import all build args are read, otherwise only known ones.
+func (r *appResource) readBuild(ctx context.Context, model *appResourceModel, readAll bool) (diags diag.Diagnostics) {
+	build := buildModel{}
+	if model.Build != nil {
+		build = *model.Build
+	}
+	// absent builder plugins are ignored on import
+	settings := []struct {
+		attribute string
+		value     *types.String
+		builder   string
+		title     string
+	}{
+		{"builder", &build.Builder, "", "Builder selected"},
+		{"build_dir", &build.BuildDir, "", "Builder build dir"},
+		{"dockerfile_path", &build.DockerfilePath, "dockerfile", "Builder dockerfile dockerfile path"},
+		{"nixpacks_toml_path", &build.NixpacksTomlPath, "nixpacks", "Builder nixpacks nixpackstoml path"},
+	}
+	reports := make(map[string]map[string]string)
+	for _, setting := range settings {
+		if setting.value.IsNull() && !readAll {
+			continue
+		}
+		report, ok := reports[setting.builder]
+		if !ok {
+			var err error
+			report, err = r.client.BuilderReport(ctx, model.AppName.ValueString(), setting.builder)
+			if err != nil {
+				if !setting.value.IsNull() {
+					diags.AddAttributeError(path.Root("build").AtName(setting.attribute), "Unable to get build settings", "Unable to get build settings. "+err.Error())
+				}
+				continue
+			}
+			reports[setting.builder] = report
+		}
+		*setting.value = stringValueOrNull(report[setting.title])
+	}
+
+	if build.BuildArgs != nil || build.SensitiveBuildArgs != nil || readAll {
+		args, err := r.client.BuildArgs(ctx, model.AppName.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("build").AtName("build_args"), "Unable to get build args", "Unable to get build args. "+err.Error())
+		} else {
+			buildArgs := make(map[string]types.String)
+			sensitiveBuildArgs := make(map[string]types.String)
+			for name, value := range args {
+				if _, ok := build.SensitiveBuildArgs[name]; ok {
+					sensitiveBuildArgs[name] = basetypes.NewStringValue(value)
+				} else if _, ok := build.BuildArgs[name]; ok || readAll {
+					buildArgs[name] = basetypes.NewStringValue(value)
+				}
+			}
+			build.BuildArgs = buildArgs
+			if len(buildArgs) == 0 {
+				build.BuildArgs = nil
+			}
+			build.SensitiveBuildArgs = sensitiveBuildArgs
+			if len(sensitiveBuildArgs) == 0 {
+				build.SensitiveBuildArgs = nil
+			}
+		}
+	}
+
+	if model.Build == nil && build.Builder.IsNull() && build.BuildDir.IsNull() && build.DockerfilePath.IsNull() && build.NixpacksTomlPath.IsNull() && build.BuildArgs == nil {
+		return
+	}
+	model.Build = &build
+	return
+}
+
+// applyBuild sets build settings of plan that are different from state.
+func (r *appResource) applyBuild(ctx context.Context, appName string, plan *buildModel, state *buildModel) (changed bool, diags diag.Diagnostics) {
+	if plan == nil {
+		plan = &buildModel{}
+	}
+	if state == nil {
+		state = &buildModel{}
+	}
+
+	settings := []struct {
+		attribute  string
+		planValue  types.String
+		stateValue types.String
+		builder    string
+		property   string
+	}{
+		{"builder", plan.Builder, state.Builder, "", "selected"},
+		{"build_dir", plan.BuildDir, state.BuildDir, "", "build-dir"},
+		{"dockerfile_path", plan.DockerfilePath, state.DockerfilePath, "dockerfile", "dockerfile-path"},
+		{"nixpacks_toml_path", plan.NixpacksTomlPath, state.NixpacksTomlPath, "nixpacks", "nixpackstoml-path"},
+	}
+	for _, setting := range settings {
+		if setting.planValue.Equal(setting.stateValue) {
+			continue
+		}
+		// null value resets setting to default
+		changed = true
+		err := r.client.BuilderSet(ctx, appName, setting.builder, setting.property, setting.planValue.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("build").AtName(setting.attribute), "Unable to set build setting", "Unable to set build setting. "+err.Error())
+		}
+	}
+
+	planBuildArgs := plan.buildArgs()
+	stateBuildArgs := state.buildArgs()
+	for name, stateArg := range stateBuildArgs {
+		if planArg, ok := planBuildArgs[name]; ok && planArg.Value == stateArg.Value {
+			continue
+		}
+		changed = true
+		err := r.client.BuildArgRemove(ctx, appName, name, stateArg.Value, stateArg.Sensitive)
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(path.Root("deploy"), "Unable to deploy", "Unable to deploy. "+err.Error())
+			diags.AddAttributeError(path.Root("build").AtName("build_args").AtMapKey(name), "Unable to remove build arg", "Unable to remove build arg. "+err.Error())
 		}
 	}
-
-	if resp.Diagnostics.HasError() {
-		err := r.client.AppDestroy(ctx, plan.AppName.ValueString())
+	for name, planArg := range planBuildArgs {
+		if stateArg, ok := stateBuildArgs[name]; ok && planArg.Value == stateArg.Value {
+			continue
+		}
+		changed = true
+		err := r.client.BuildArgAdd(ctx, appName, name, planArg.Value, planArg.Sensitive)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to destroy app", "Unable to destroy app. "+err.Error())
+			diags.AddAttributeError(path.Root("build").AtName("build_args").AtMapKey(name), "Unable to add build arg", "Unable to add build arg. "+err.Error())
 		}
-		return
 	}
+	return
+}
 
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+// isDokkuConfigKey reports whether config key is set by dokku itself or by other attributes (i.e. checks), so it is not imported and not removed in authoritative mode.
+func isDokkuConfigKey(key string) bool {
+	return strings.HasPrefix(key, "DOKKU_") || key == "GIT_REV"
 }
 
-// Update updates the resource and sets the updated Terraform state on success.
-func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Retrieve values from plan
-	var plan appResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+// unmanagedConfigKeys returns sorted keys of config that are not managed and should be removed in authoritative mode.
+func unmanagedConfigKeys(config map[string]string, managedKeys []string) []string {
+	var res []string
+	for k, v := range config {
+		if !slices.Contains(managedKeys, k) && !isDokkuConfigKey(k) && !isLinkConfigKey(k, v) {
+			res = append(res, k)
+		}
 	}
-	var state appResourceModel
-	diags = req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	slices.Sort(res)
+	return res
+}
+
+// isLinkConfigKey reports whether config key is set by service link (i.e. DATABASE_URL set by dokku_postgres_link), so it is not imported and not removed in authoritative mode.
+func isLinkConfigKey(key string, value string) bool {
+	return strings.HasSuffix(key, "_URL") && dokkuclient.IsSimpleServiceLinkURL(value)
+}
+
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return basetypes.NewStringNull()
 	}
+	return basetypes.NewStringValue(value)
+}
 
-	if plan.AppName.ValueString() != state.AppName.ValueString() {
-		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "App name can't be changed", "App name can't be changed")
-		return
+func int64ValueOrNull(value string) types.Int64 {
+	res, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return basetypes.NewInt64Null()
 	}
-	appName := plan.AppName.ValueString()
+	return basetypes.NewInt64Value(res)
+}
 
-	restartRequired := false
+func stringSetValueOrNull(values []string) (types.Set, diag.Diagnostics) {
+	if len(values) == 0 {
+		return basetypes.NewSetNull(types.StringType), nil
+	}
+	var elements []attr.Value
+	for _, value := range values {
+		elements = append(elements, basetypes.NewStringValue(value))
+	}
+	return basetypes.NewSetValue(types.StringType, elements)
+}
 
-	// -- config
-	var namesToUnset []string
-	for stateName := range state.Config {
-		found := false
-		for planName := range plan.Config {
-			if planName == stateName {
-				found = true
-				break
-			}
+// applyChecks changes checks settings of app from state to plan. Nil state means that defaults are used.
+func (r *appResource) applyChecks(ctx context.Context, appName string, plan *checkModel, state *checkModel) (changed bool, diags diag.Diagnostics) {
+	planDisabled, planSkipped := plan.processTypes("disabled"), plan.processTypes("skipped")
+	if plan.status() != state.status() || !slices.Equal(planDisabled, state.processTypes("disabled")) || !slices.Equal(planSkipped, state.processTypes("skipped")) {
+		// status for all process types resets per-process settings, so they are applied after it
+		changed = true
+		err := r.client.ChecksSet(ctx, appName, plan.status())
+		if err == nil && len(planDisabled) != 0 {
+			err = r.client.ChecksSetForProcesses(ctx, appName, "disabled", planDisabled)
 		}
-		if !found {
-			namesToUnset = append(namesToUnset, stateName)
+		if err == nil && len(planSkipped) != 0 {
+			err = r.client.ChecksSetForProcesses(ctx, appName, "skipped", planSkipped)
+		}
+		if err != nil {
+			diags.AddAttributeError(path.Root("checks"), "Unable to set checks", "Unable to set checks. "+err.Error())
 		}
 	}
-	if len(namesToUnset) != 0 {
-		err := r.client.ConfigUnset(ctx, appName, namesToUnset)
+
+	planWaitToRetire, stateWaitToRetire := basetypes.NewInt64Null(), basetypes.NewInt64Null()
+	if plan != nil {
+		planWaitToRetire = plan.WaitToRetire
+	}
+	if state != nil {
+		stateWaitToRetire = state.WaitToRetire
+	}
+	if !planWaitToRetire.Equal(stateWaitToRetire) {
+		value := ""
+		if !planWaitToRetire.IsNull() {
+			value = strconv.FormatInt(planWaitToRetire.ValueInt64(), 10)
+		}
+		changed = true
+		err := r.client.ChecksSetProperty(ctx, appName, "wait-to-retire", value)
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to unset config", "Unable to unset config. "+err.Error())
+			diags.AddAttributeError(path.Root("checks").AtName("wait_to_retire"), "Unable to set checks wait to retire", "Unable to set checks wait to retire. "+err.Error())
 		}
-		restartRequired = true
 	}
 
 	configToSet := make(map[string]string)
-	for k, v := range plan.Config {
-		if !state.Config[k].Equal(v) {
-			configToSet[k] = v.ValueString()
+	var configToUnset []string
+	planConfig, stateConfig := plan.configValues(), state.configValues()
+	for i, name := range checksConfigVars {
+		if planConfig[i] == stateConfig[i] {
+			continue
+		}
+		if planConfig[i] == "" {
+			configToUnset = append(configToUnset, name)
+		} else {
+			configToSet[name] = planConfig[i]
 		}
 	}
 	if len(configToSet) != 0 {
+		changed = true
 		err := r.client.ConfigSet(ctx, appName, configToSet)
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to set config", "Unable to set config. "+err.Error())
+			diags.AddAttributeError(path.Root("checks"), "Unable to set checks config", "Unable to set checks config. "+err.Error())
 		}
-		restartRequired = true
 	}
-	// --
+	if len(configToUnset) != 0 {
+		changed = true
+		err := r.client.ConfigUnset(ctx, appName, configToUnset)
+		if err != nil {
+			diags.AddAttributeError(path.Root("checks"), "Unable to unset checks config", "Unable to unset checks config. "+err.Error())
+		}
+	}
+	return
+}
+
+// withoutStorageLocalDirectories returns copy of model without storage local_directory, so only storage mounts are applied.
+func withoutStorageLocalDirectories(model appResourceModel) *appResourceModel {
+	if model.Storage != nil {
+		storage := make(map[string]storageModel, len(model.Storage))
+		for name, storageConfig := range model.Storage {
+			storageConfig.LocalDirectory = basetypes.NewStringNull()
+			storage[name] = storageConfig
+		}
+		model.Storage = storage
+	}
+	return &model
+}
 
-	// -- storage
+// applyStorage applies changes of storage mounts from state to plan.
+func (r *appResource) applyStorage(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
 	for existingName, existingStorage := range state.Storage {
 		found := false
 		for planName, planStorage := range plan.Storage {
@@ -798,27 +2581,27 @@ func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, re
 				if !existingStorage.MountPath.Equal(planStorage.MountPath) {
 					err := r.client.StorageUnmount(ctx, appName, existingName, existingStorage.MountPath.ValueString())
 					if err != nil {
-						resp.Diagnostics.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to unmount storage", "Unable to unmount storage. "+err.Error())
+						diags.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to unmount storage", "Unable to unmount storage. "+err.Error())
 					}
 
 					err = r.client.StorageEnsure(ctx, planName, planStorage.LocalDirectory.ValueStringPointer())
 					if err != nil {
-						resp.Diagnostics.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to ensure storage", "Unable to ensure storage. "+err.Error())
+						diags.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to ensure storage", "Unable to ensure storage. "+err.Error())
 					}
 
 					err = r.client.StorageMount(ctx, appName, planName, planStorage.MountPath.ValueString())
 					if err != nil {
-						resp.Diagnostics.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to mount storage", "Unable to mount storage. "+err.Error())
+						diags.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to mount storage", "Unable to mount storage. "+err.Error())
 					}
 
-					restartRequired = true
+					changed = true
 				} else if !planStorage.LocalDirectory.IsNull() {
 					err := r.client.StorageEnsure(ctx, planName, planStorage.LocalDirectory.ValueStringPointer())
 					if err != nil {
-						resp.Diagnostics.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to ensure storage", "Unable to ensure storage. "+err.Error())
+						diags.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to ensure storage", "Unable to ensure storage. "+err.Error())
 					}
 
-					restartRequired = true
+					changed = true
 				}
 
 				break
@@ -827,10 +2610,10 @@ func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		if !found {
 			err := r.client.StorageUnmount(ctx, appName, existingName, existingStorage.MountPath.ValueString())
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to unmount storage", "Unable to unmount storage. "+err.Error())
+				diags.AddAttributeError(path.Root("storage").AtMapKey(existingName), "Unable to unmount storage", "Unable to unmount storage. "+err.Error())
 			}
 
-			restartRequired = true
+			changed = true
 		}
 	}
 	for planName, planStorage := range plan.Storage {
@@ -844,39 +2627,25 @@ func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		if !found {
 			err := r.client.StorageEnsure(ctx, planName, planStorage.LocalDirectory.ValueStringPointer())
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("storage").AtMapKey(planName), "Unable to ensure storage", "Unable to ensure storage. "+err.Error())
+				diags.AddAttributeError(path.Root("storage").AtMapKey(planName), "Unable to ensure storage", "Unable to ensure storage. "+err.Error())
 			}
 
 			err = r.client.StorageMount(ctx, appName, planName, planStorage.MountPath.ValueString())
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("storage").AtMapKey(planName), "Unable to mount storage", "Unable to mount storage. "+err.Error())
+				diags.AddAttributeError(path.Root("storage").AtMapKey(planName), "Unable to mount storage", "Unable to mount storage. "+err.Error())
 			}
 
-			restartRequired = true
-		}
-	}
-	// --
-
-	// -- checks
-	stateCheckStatus := "enabled"
-	if state.Checks != nil {
-		stateCheckStatus = state.Checks.Status.ValueString()
-	}
-	planCheckStatus := "enabled"
-	if plan.Checks != nil {
-		planCheckStatus = plan.Checks.Status.ValueString()
-	}
-	if stateCheckStatus != planCheckStatus {
-		err := r.client.ChecksSet(ctx, appName, planCheckStatus)
-		if err != nil {
-			resp.Diagnostics.AddAttributeError(path.Root("checks"), "Unable to set checks", "Unable to set checks. "+err.Error())
+			changed = true
 		}
 	}
-	// --
+	return
+}
 
-	// -- ports
-	if len(plan.ProxyPorts) > 0 {
-		resp.Diagnostics.AddAttributeWarning(path.Root("proxy_ports"), "proxy_ports attribute is deprecated, use ports attribute instead", "proxy_ports attribute is deprecated, use ports attribute instead")
+// applyPorts applies changes of ports from state to plan.
+func (r *appResource) applyPorts(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
+	// ports that are not set are not managed here (i.e. by dokku_app_ports resource)
+	if plan.Ports == nil && plan.ProxyPorts == nil {
+		return
 	}
 	needToSetPorts := false
 	var portsToSet []dokkuclient.Port
@@ -941,31 +2710,38 @@ func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		})
 	}
 	if needToSetPorts {
+		changed = true
 		if len(portsToSet) == 0 {
 			err := r.client.PortsClear(ctx, appName)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("ports"), "Unable to clear ports", "Unable to clear ports. "+err.Error())
+				diags.AddAttributeError(path.Root("ports"), "Unable to clear ports", "Unable to clear ports. "+err.Error())
 			}
 
 			err = r.client.ProxyDisable(ctx, appName)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("ports"), "Unable to disable ports", "Unable to disable ports. "+err.Error())
+				diags.AddAttributeError(path.Root("ports"), "Unable to disable ports", "Unable to disable ports. "+err.Error())
 			}
 		} else {
 			err := r.client.PortsSet(ctx, appName, portsToSet)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("ports"), "Unable to set ports", "Unable to set ports. "+err.Error())
+				diags.AddAttributeError(path.Root("ports"), "Unable to set ports", "Unable to set ports. "+err.Error())
 			}
 
 			err = r.client.ProxyEnable(ctx, appName)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("ports"), "Unable to enable ports", "Unable to enable ports. "+err.Error())
+				diags.AddAttributeError(path.Root("ports"), "Unable to enable ports", "Unable to enable ports. "+err.Error())
 			}
 		}
 	}
-	// --
+	return
+}
 
-	// -- domains
+// applyDomains applies changes of domains from state to plan.
+func (r *appResource) applyDomains(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
+	// domains that are not set are not managed here (i.e. by dokku_app_domains resource)
+	if plan.Domains == nil {
+		return
+	}
 	needToSetDomains := false
 	var domainsToSet []string
 	for _, existingDomain := range state.Domains {
@@ -994,33 +2770,36 @@ func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		domainsToSet = append(domainsToSet, planDomain.ValueString())
 	}
 	if needToSetDomains {
+		changed = true
 		var err error
 		if len(domainsToSet) == 0 {
 			err = r.client.DomainsDisable(ctx, appName)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to disable domains support", "Unable to disable domains support. "+err.Error())
+				diags.AddAttributeError(path.Root("domains"), "Unable to disable domains support", "Unable to disable domains support. "+err.Error())
 			}
 
 			err = r.client.DomainsClear(ctx, appName)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to clear domains", "Unable to clear domains. "+err.Error())
+				diags.AddAttributeError(path.Root("domains"), "Unable to clear domains", "Unable to clear domains. "+err.Error())
 			}
 		} else {
 			err = r.client.DomainsEnable(ctx, appName)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to enable domains support", "Unable to enable domains support. "+err.Error())
+				diags.AddAttributeError(path.Root("domains"), "Unable to enable domains support", "Unable to enable domains support. "+err.Error())
 			}
 
 			err = r.client.DomainsSet(ctx, appName, domainsToSet)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("domains"), "Unable to set domains", "Unable to set domains. "+err.Error())
+				diags.AddAttributeError(path.Root("domains"), "Unable to set domains", "Unable to set domains. "+err.Error())
 			}
 		}
 	}
 	// TODO run letsencrypt:enable again after adding new domains
-	// --
+	return
+}
 
-	// -- docker options
+// applyDockerOptions applies changes of docker options from state to plan.
+func (r *appResource) applyDockerOptions(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
 	for existingValue, existingDockerOption := range state.DockerOptions {
 		found := false
 		for planValue, planDockerOption := range plan.DockerOptions {
@@ -1030,15 +2809,15 @@ func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, re
 				if !existingDockerOption.Phase.Equal(planDockerOption.Phase) {
 					err := r.client.DockerOptionRemove(ctx, appName, formatDockerOptionsPhases(existingDockerOption.Phase), existingValue)
 					if err != nil {
-						resp.Diagnostics.AddAttributeError(path.Root("storage").AtMapKey(existingValue), "Unable to remove docker option", "Unable to remove docker option. "+err.Error())
+						diags.AddAttributeError(path.Root("storage").AtMapKey(existingValue), "Unable to remove docker option", "Unable to remove docker option. "+err.Error())
 					}
 
 					err = r.client.DockerOptionAdd(ctx, appName, formatDockerOptionsPhases(planDockerOption.Phase), planValue)
 					if err != nil {
-						resp.Diagnostics.AddAttributeError(path.Root("storage").AtMapKey(existingValue), "Unable to add docker option", "Unable to add docker option. "+err.Error())
+						diags.AddAttributeError(path.Root("storage").AtMapKey(existingValue), "Unable to add docker option", "Unable to add docker option. "+err.Error())
 					}
 
-					restartRequired = true
+					changed = true
 				}
 
 				break
@@ -1047,10 +2826,10 @@ func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		if !found {
 			err := r.client.DockerOptionRemove(ctx, appName, formatDockerOptionsPhases(existingDockerOption.Phase), existingValue)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("docker_options").AtMapKey(existingValue), "Unable to remove docker option", "Unable to remove docker option. "+err.Error())
+				diags.AddAttributeError(path.Root("docker_options").AtMapKey(existingValue), "Unable to remove docker option", "Unable to remove docker option. "+err.Error())
 			}
 
-			restartRequired = true
+			changed = true
 		}
 	}
 	for planValue, planDockerOption := range plan.DockerOptions {
@@ -1064,174 +2843,327 @@ func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		if !found {
 			err := r.client.DockerOptionAdd(ctx, appName, formatDockerOptionsPhases(planDockerOption.Phase), planValue)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root("docker_options").AtMapKey(planValue), "Unable to add docker option", "Unable to add docker option. "+err.Error())
+				diags.AddAttributeError(path.Root("docker_options").AtMapKey(planValue), "Unable to add docker option", "Unable to add docker option. "+err.Error())
 			}
 
-			restartRequired = true
+			changed = true
 		}
 	}
-	// --
+	return
+}
 
-	// -- networks
+// applyNetworks applies changes of networks from state to plan.
+func (r *appResource) applyNetworks(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
 	if state.Networks != nil {
 		if plan.Networks != nil {
 			if !plan.Networks.AttachPostCreate.Equal(state.Networks.AttachPostCreate) {
+				changed = true
 				err := r.client.NetworkEnsureAndSetForApp(ctx, appName, "attach-post-create", plan.Networks.AttachPostCreate.ValueString())
 				if err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root("networks").AtName("attach_post_create"), "Unable to set network", "Unable to set network. "+err.Error())
+					diags.AddAttributeError(path.Root("networks").AtName("attach_post_create"), "Unable to set network", "Unable to set network. "+err.Error())
 				}
 			}
 			if !plan.Networks.AttachPostDeploy.Equal(state.Networks.AttachPostDeploy) {
+				changed = true
 				err := r.client.NetworkEnsureAndSetForApp(ctx, appName, "attach-post-deploy", plan.Networks.AttachPostDeploy.ValueString())
 				if err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root("networks").AtName("attach_post_deploy"), "Unable to set network", "Unable to set network. "+err.Error())
+					diags.AddAttributeError(path.Root("networks").AtName("attach_post_deploy"), "Unable to set network", "Unable to set network. "+err.Error())
 				}
 			}
 			if !plan.Networks.InitialNetwork.Equal(state.Networks.InitialNetwork) {
+				changed = true
 				err := r.client.NetworkEnsureAndSetForApp(ctx, appName, "initial-network", plan.Networks.InitialNetwork.ValueString())
 				if err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root("networks").AtName("initial_network"), "Unable to set network", "Unable to set network. "+err.Error())
+					diags.AddAttributeError(path.Root("networks").AtName("initial_network"), "Unable to set network", "Unable to set network. "+err.Error())
 				}
 			}
 		} else {
 			if !state.Networks.AttachPostCreate.IsNull() {
+				changed = true
 				err := r.client.NetworkUnsetForApp(ctx, appName, "attach-post-create")
 				if err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root("networks").AtName("attach_post_create"), "Unable to unset network", "Unable to unset network. "+err.Error())
+					diags.AddAttributeError(path.Root("networks").AtName("attach_post_create"), "Unable to unset network", "Unable to unset network. "+err.Error())
 				}
 			}
 			if !state.Networks.AttachPostDeploy.IsNull() {
+				changed = true
 				err := r.client.NetworkUnsetForApp(ctx, appName, "attach-post-deploy")
 				if err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root("networks").AtName("attach_post_deploy"), "Unable to unset network", "Unable to unset network. "+err.Error())
+					diags.AddAttributeError(path.Root("networks").AtName("attach_post_deploy"), "Unable to unset network", "Unable to unset network. "+err.Error())
 				}
 			}
 			if !state.Networks.InitialNetwork.IsNull() {
+				changed = true
 				err := r.client.NetworkUnsetForApp(ctx, appName, "initial-network")
 				if err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root("networks").AtName("initial_network"), "Unable to unset network", "Unable to unset network. "+err.Error())
+					diags.AddAttributeError(path.Root("networks").AtName("initial_network"), "Unable to unset network", "Unable to unset network. "+err.Error())
 				}
 			}
 		}
 	} else {
 		if plan.Networks != nil {
 			if !plan.Networks.AttachPostCreate.IsNull() {
+				changed = true
 				err := r.client.NetworkEnsureAndSetForApp(ctx, appName, "attach-post-create", plan.Networks.AttachPostCreate.ValueString())
 				if err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root("networks").AtName("attach_post_create"), "Unable to set network", "Unable to set network. "+err.Error())
+					diags.AddAttributeError(path.Root("networks").AtName("attach_post_create"), "Unable to set network", "Unable to set network. "+err.Error())
 				}
 			}
 			if !plan.Networks.AttachPostDeploy.IsNull() {
+				changed = true
 				err := r.client.NetworkEnsureAndSetForApp(ctx, appName, "attach-post-deploy", plan.Networks.AttachPostDeploy.ValueString())
 				if err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root("networks").AtName("attach_post_deploy"), "Unable to set network", "Unable to set network. "+err.Error())
+					diags.AddAttributeError(path.Root("networks").AtName("attach_post_deploy"), "Unable to set network", "Unable to set network. "+err.Error())
 				}
 			}
 			if !plan.Networks.InitialNetwork.IsNull() {
+				changed = true
 				err := r.client.NetworkEnsureAndSetForApp(ctx, appName, "initial-network", plan.Networks.InitialNetwork.ValueString())
 				if err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root("networks").AtName("initial_network"), "Unable to set network", "Unable to set network. "+err.Error())
+					diags.AddAttributeError(path.Root("networks").AtName("initial_network"), "Unable to set network", "Unable to set network. "+err.Error())
 				}
 			}
 		}
 	}
-	// --
+	return
+}
 
-	// -- deploy
-	if plan.Deploy != nil {
-		deployed, err := r.deploy(ctx, plan.AppName.ValueString(), *plan.Deploy)
+// applyProcesses applies changes of process scaling from state to plan.
+func (r *appResource) applyProcesses(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
+	processesToScale := make(map[string]types.Int64)
+	for processType, count := range plan.Processes {
+		if !state.Processes[processType].Equal(count) {
+			processesToScale[processType] = count
+		}
+	}
+	if len(processesToScale) != 0 {
+		err := r.client.ProcessScaleSet(ctx, appName, formatProcesses(processesToScale))
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(path.Root("deploy"), "Unable to deploy", "Unable to deploy. "+err.Error())
+			diags.AddAttributeError(path.Root("processes"), "Unable to scale processes", "Unable to scale processes. "+err.Error())
 		}
-		if deployed {
-			restartRequired = false
+		changed = true
+	}
+	return
+}
+
+// applyRestartPolicy applies change of restart policy from state to plan.
+func (r *appResource) applyRestartPolicy(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
+	if !plan.RestartPolicy.Equal(state.RestartPolicy) {
+		// null value resets restart policy to default
+		err := r.client.ProcessSet(ctx, appName, "restart-policy", plan.RestartPolicy.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("restart_policy"), "Unable to set restart policy", "Unable to set restart policy. "+err.Error())
 		}
+		changed = true
 	}
-	// --
+	return
+}
 
-	if !resp.Diagnostics.HasError() && restartRequired {
-		err := r.client.ProcessRestart(ctx, appName)
+// applyProcessSettings applies changes of stop timeout, procfile and app.json paths from state to plan.
+func (r *appResource) applyProcessSettings(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
+	// settings are used on next deploy or stop of containers, so restart is not required
+	if !plan.StopTimeoutSeconds.Equal(state.StopTimeoutSeconds) {
+		stopTimeoutSeconds := ""
+		if !plan.StopTimeoutSeconds.IsNull() {
+			stopTimeoutSeconds = plan.StopTimeoutSeconds.String()
+		}
+		err := r.client.ProcessSet(ctx, appName, "stop-timeout-seconds", stopTimeoutSeconds)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+			diags.AddAttributeError(path.Root("stop_timeout_seconds"), "Unable to set stop timeout", "Unable to set stop timeout. "+err.Error())
 		}
+		changed = true
 	}
-	if resp.Diagnostics.HasError() {
-		return
+	if !plan.ProcfilePath.Equal(state.ProcfilePath) {
+		// null value resets path to default
+		err := r.client.ProcessSet(ctx, appName, "procfile-path", plan.ProcfilePath.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("procfile_path"), "Unable to set procfile path", "Unable to set procfile path. "+err.Error())
+		}
+		changed = true
 	}
+	if !plan.AppJsonPath.Equal(state.AppJsonPath) {
+		err := r.client.AppJsonSet(ctx, appName, "appjson-path", plan.AppJsonPath.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("app_json_path"), "Unable to set app.json path", "Unable to set app.json path. "+err.Error())
+		}
+		changed = true
+	}
+	return
+}
 
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+// applyResources applies changes of resource limits and reservations from state to plan.
+func (r *appResource) applyResources(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
+	resourcesProcessTypes := make(map[string]struct{})
+	for processType := range state.Resources {
+		resourcesProcessTypes[processType] = struct{}{}
+	}
+	for processType := range plan.Resources {
+		resourcesProcessTypes[processType] = struct{}{}
+	}
+	for processType := range resourcesProcessTypes {
+		// missing process types are cleared
+		planResources := plan.Resources[processType]
+		stateResources := state.Resources[processType]
+		if planResources.Limit.values() != stateResources.Limit.values() {
+			err := r.client.ResourceLimitSet(ctx, appName, processType, planResources.Limit.values())
+			if err != nil {
+				diags.AddAttributeError(path.Root("resources").AtMapKey(processType).AtName("limit"), "Unable to set resource limit", "Unable to set resource limit. "+err.Error())
+			}
+			changed = true
+		}
+		if planResources.Reserve.values() != stateResources.Reserve.values() {
+			err := r.client.ResourceReserveSet(ctx, appName, processType, planResources.Reserve.values())
+			if err != nil {
+				diags.AddAttributeError(path.Root("resources").AtMapKey(processType).AtName("reserve"), "Unable to set resource reservation", "Unable to set resource reservation. "+err.Error())
+			}
+			changed = true
+		}
 	}
+	return
 }
 
-// Delete deletes the resource and removes the Terraform state on success.
-func (r *appResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Retrieve values from state
-	var state appResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+// applyBuildpacks applies changes of buildpacks from state to plan.
+func (r *appResource) applyBuildpacks(ctx context.Context, appName string, plan *appResourceModel, state *appResourceModel) (changed bool, diags diag.Diagnostics) {
+	if !slices.Equal(formatBuildpacks(plan.Buildpacks), formatBuildpacks(state.Buildpacks)) {
+		changed = true
+		err := r.client.BuildpacksSet(ctx, appName, formatBuildpacks(plan.Buildpacks))
+		if err != nil {
+			diags.AddAttributeError(path.Root("buildpacks"), "Unable to set buildpacks", "Unable to set buildpacks. "+err.Error())
+		}
 	}
+	if !plan.BuildpackStack.Equal(state.BuildpackStack) {
+		// null value resets stack to default
+		changed = true
+		err := r.client.BuildpacksSetStack(ctx, appName, plan.BuildpackStack.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("buildpack_stack"), "Unable to set buildpack stack", "Unable to set buildpack stack. "+err.Error())
+		}
+	}
+	return
+}
 
-	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+// readDeployedRevision sets deployed_* attributes of model. They are set to null if app is not deployed or on error.
+func (r *appResource) readDeployedRevision(ctx context.Context, model *appResourceModel) (info dokkuclient.DeployInfo, deployed bool, err error) {
+	model.DeployedImage = basetypes.NewStringNull()
+	model.DeployedGitSha = basetypes.NewStringNull()
+	model.DeployedAt = basetypes.NewStringNull()
+
+	deployed, err = r.client.ProcessIsDeployed(ctx, model.AppName.ValueString())
+	if err != nil || !deployed {
 		return
 	}
-	if !exists {
+	info, err = r.client.DeployReport(ctx, model.AppName.ValueString())
+	if err != nil {
 		return
 	}
 
-	// Delete existing app
-	err = r.client.AppDestroy(ctx, state.AppName.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to destroy app", "Unable to destroy app. "+err.Error())
+	if info.SourceImage != "" {
+		model.DeployedImage = basetypes.NewStringValue(info.SourceImage)
+	}
+	if info.GitSha != "" {
+		model.DeployedGitSha = basetypes.NewStringValue(info.GitSha)
+	}
+	if info.LastUpdatedAt != "" {
+		model.DeployedAt = basetypes.NewStringValue(info.LastUpdatedAt)
+	}
+	return
+}
+
+// deployedRevision returns information about deployed revision. It is empty if app is not deployed.
+func (r *appResource) deployedRevision(ctx context.Context, appName string) (info dokkuclient.DeployInfo, err error) {
+	deployed, err := r.client.ProcessIsDeployed(ctx, appName)
+	if err != nil || !deployed {
 		return
 	}
+	return r.client.DeployReport(ctx, appName)
 }
 
-func (r *appResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to app_name attribute
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), req.ID)...)
+// appRollbackStep reverts change applied by update and returns description of it. Empty description means that there was nothing to revert.
+type appRollbackStep func(ctx context.Context) (reverted string, diags diag.Diagnostics)
+
+// rollback reverts applied changes in reverse order and reports what was rolled back.
+func (r *appResource) rollback(ctx context.Context, steps []appRollbackStep) (diags diag.Diagnostics) {
+	var reverted, notReverted []string
+	for i := len(steps) - 1; i >= 0; i-- {
+		description, stepDiags := steps[i](ctx)
+		diags.Append(stepDiags...)
+		if stepDiags.HasError() {
+			notReverted = append(notReverted, description)
+		} else if description != "" {
+			reverted = append(reverted, description)
+		}
+	}
+
+	details := "No changes were applied before failure."
+	if len(reverted) != 0 {
+		details = "Reverted changes:\n- " + strings.Join(reverted, "\n- ")
+	}
+	if len(notReverted) != 0 {
+		diags.AddError("Unable to roll back changes", "Update failed and some of applied changes could not be reverted, so app could be left partially updated.\nNot reverted changes:\n- "+strings.Join(notReverted, "\n- ")+"\n"+details)
+		return
+	}
+	diags.AddWarning("Changes are rolled back", "Update failed, so applied changes are reverted and app is left as it was before update.\n"+details)
+	return
 }
 
-func (r *appResource) deploy(ctx context.Context, appName string, deployModel deployModel) (deployed bool, err error) {
-	switch deployModel.Type.ValueString() {
-	case "archive":
-		err = r.client.DeployFromArchive(ctx, appName, deployModel.ArchiveType.ValueString(), deployModel.ArchiveUrl.ValueString())
-		deployed = err == nil
-	case "docker_image":
-		if !deployModel.Login.IsNull() && !deployModel.Password.IsNull() {
-			u, err := url.Parse("https://" + deployModel.DockerImage.ValueString())
-			if err != nil {
-				return false, fmt.Errorf("unable to parse url: %w", err)
-			}
-			err = r.client.RegistryLogin(ctx, u.Host, deployModel.Login.ValueString(), deployModel.Password.ValueString())
-			if err != nil {
-				return false, fmt.Errorf("unable to login to registry: %w", err)
-			}
+// revertConfig sets config values from snapshot taken before update. Names missing in snapshot are unset.
+func (r *appResource) revertConfig(ctx context.Context, appName string, snapshot map[string]string, names []string) (diags diag.Diagnostics) {
+	configToSet := make(map[string]string)
+	var namesToUnset []string
+	for _, name := range names {
+		if value, ok := snapshot[name]; ok {
+			configToSet[name] = value
+		} else {
+			namesToUnset = append(namesToUnset, name)
+		}
+	}
+	if len(namesToUnset) != 0 {
+		err := r.client.ConfigUnset(ctx, appName, namesToUnset)
+		if err != nil {
+			diags.AddAttributeError(path.Root("config"), "Unable to revert config", "Unable to revert config. "+err.Error())
+		}
+	}
+	if len(configToSet) != 0 {
+		err := r.client.ConfigSet(ctx, appName, configToSet)
+		if err != nil {
+			diags.AddAttributeError(path.Root("config"), "Unable to revert config", "Unable to revert config. "+err.Error())
 		}
+	}
+	return
+}
 
-		deployed, err = r.client.DeployFromImage(ctx, appName, deployModel.DockerImage.ValueString(), deployModel.AllowRebuild.ValueBool())
-	case "git_repository":
-		if !deployModel.Login.IsNull() && !deployModel.Password.IsNull() {
-			u, err := url.Parse(deployModel.GitRepository.ValueString())
-			if err != nil {
-				return false, fmt.Errorf("unable to parse url: %w", err)
-			}
-			err = r.client.GitAuth(ctx, u.Host, deployModel.Login.ValueString(), deployModel.Password.ValueString())
-			if err != nil {
-				return false, fmt.Errorf("unable to login to git: %w", err)
-			}
+// revertDeploy restores running app after settings are reverted.
+// Previous image is redeployed if deployed revision is changed, otherwise app is restarted if its containers could use new settings.
+func (r *appResource) revertDeploy(ctx context.Context, appName string, previous dokkuclient.DeployInfo, deployAttempted bool, restarted bool) (reverted string, diags diag.Diagnostics) {
+	revisionChanged := false
+	if deployAttempted {
+		current, err := r.deployedRevision(ctx, appName)
+		if err != nil {
+			diags.AddAttributeError(path.Root("deploy"), "Unable to get deployed revision", "Unable to get deployed revision. "+err.Error())
+			return "deployed revision", diags
 		}
+		revisionChanged = current.SourceImage != previous.SourceImage || current.GitSha != previous.GitSha
+	}
 
-		err = r.client.DeploySyncRepository(ctx, appName, deployModel.GitRepository.ValueString(), deployModel.GitRepositoryRef.ValueString())
-		deployed = err == nil
-	default:
-		err = fmt.Errorf("Unknown deploy type %s", deployModel.Type.ValueString())
+	switch {
+	case revisionChanged && previous.SourceImage != "":
+		reverted = "deployed revision (previous image " + previous.SourceImage + " is redeployed)"
+		_, err := r.client.DeployFromImage(ctx, appName, previous.SourceImage, true)
+		if err != nil {
+			diags.AddAttributeError(path.Root("deploy"), "Unable to redeploy previous image", "Unable to redeploy previous image. "+err.Error())
+		}
+	case revisionChanged && previous.GitSha != "":
+		reverted = "deployed revision (previous revision " + previous.GitSha + " is not deployed from docker image)"
+		diags.AddAttributeError(path.Root("deploy"), "Unable to redeploy previous revision", "Unable to redeploy previous revision. It is not deployed from docker image, so it should be deployed again manually")
+	case revisionChanged:
+		reverted = "deployed revision (app was not deployed before update)"
+		diags.AddAttributeError(path.Root("deploy"), "Unable to revert deploy", "Unable to revert deploy. App was not deployed before update, so new revision is kept")
+	case restarted:
+		reverted = "running containers (restarted with previous settings)"
+		err := r.client.ProcessRestart(ctx, appName)
+		if err != nil {
+			diags.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+		}
 	}
 	return
 }
@@ -1244,3 +3176,26 @@ func formatDockerOptionsPhases(phasesSet types.Set) (phases []string) {
 	}
 	return
 }
+
+func formatStringSet(set types.Set) (res []string) {
+	for _, value := range set.Elements() {
+		//nolint:forcetypeassert
+		res = append(res, value.(types.String).ValueString())
+	}
+	return
+}
+
+func formatProcesses(processes map[string]types.Int64) map[string]int64 {
+	res := make(map[string]int64)
+	for processType, count := range processes {
+		res[processType] = count.ValueInt64()
+	}
+	return res
+}
+
+func formatBuildpacks(buildpacks []types.String) (res []string) {
+	for _, buildpack := range buildpacks {
+		res = append(res, buildpack.ValueString())
+	}
+	return
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource                = &appStorageMountResource{}
+	_ resource.ResourceWithConfigure   = &appStorageMountResource{}
+	_ resource.ResourceWithImportState = &appStorageMountResource{}
+)
+
+func NewAppStorageMountResource() resource.Resource {
+	return &appStorageMountResource{}
+}
+
+type appStorageMountResource struct {
+	client *dokkuclient.Client
+}
+
+type appStorageMountResourceModel struct {
+	AppName        types.String `tfsdk:"app_name"`
+	Storage        types.String `tfsdk:"storage"`
+	MountPath      types.String `tfsdk:"mount_path"`
+	LocalDirectory types.String `tfsdk:"local_directory"`
+}
+
+// Metadata returns the resource type name.
+func (r *appStorageMountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_storage_mount"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *appStorageMountResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *appStorageMountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Persistent storage mount for existing app",
+			"Should not be used together with dokku_app.storage attribute",
+			"https://dokku.com/docs/advanced-usage/persistent-storage/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of application to mount storage to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "invalid app_name"),
+				},
+			},
+			"storage": schema.StringAttribute{
+				Required:    true,
+				Description: "Storage name or absolute path to host directory",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^:\s]+$`), "must not contain colons and spaces"),
+				},
+			},
+			"mount_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Path inside container to mount to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"local_directory": schema.StringAttribute{
+				Optional: true,
+				Description: strings.Join([]string{
+					"Uploads local directory to host (always, without checking is it changed)",
+					"",
+					"Should not be used for uploading large files, because it is slow.",
+					"Also see upload_* attributes in provider configuration.",
+				}, "\n  "),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *appStorageMountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state appStorageMountResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check app existence
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	storage, err := r.client.StorageExport(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("storage"), "Unable to get storage", "Unable to get storage. "+err.Error())
+		return
+	}
+	found := false
+	for k, mountPath := range storage {
+		// storage could be set using absolute host path (i.e. dokku_storage.host_path)
+		if k != state.Storage.ValueString() && dokkuclient.StorageHostPath(k) != state.Storage.ValueString() {
+			continue
+		}
+		if !state.MountPath.IsNull() && state.MountPath.ValueString() != mountPath {
+			continue
+		}
+		found = true
+		state.MountPath = basetypes.NewStringValue(mountPath)
+		break
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *appStorageMountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan appStorageMountResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.StorageEnsure(ctx, plan.Storage.ValueString(), plan.LocalDirectory.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("storage"), "Unable to ensure storage", "Unable to ensure storage. "+err.Error())
+		return
+	}
+
+	err = r.client.StorageMount(ctx, plan.AppName.ValueString(), plan.Storage.ValueString(), plan.MountPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("storage"), "Unable to mount storage", "Unable to mount storage. "+err.Error())
+		return
+	}
+
+	err = r.client.ProcessRestartIfDeployed(ctx, plan.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *appStorageMountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan appStorageMountResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.LocalDirectory.IsNull() {
+		err := r.client.StorageEnsure(ctx, plan.Storage.ValueString(), plan.LocalDirectory.ValueStringPointer())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("local_directory"), "Unable to ensure storage", "Unable to ensure storage. "+err.Error())
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *appStorageMountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state appStorageMountResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		return
+	}
+
+	err = r.client.StorageUnmount(ctx, state.AppName.ValueString(), state.Storage.ValueString(), state.MountPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("storage"), "Unable to unmount storage", "Unable to unmount storage. "+err.Error())
+		return
+	}
+
+	err = r.client.ProcessRestartIfDeployed(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to restart process", "Unable to restart process. "+err.Error())
+		return
+	}
+}
+
+func (r *appStorageMountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID in format app_name:storage
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Import ID should be in format app_name:storage")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("storage"), parts[1])...)
+}
@@ -0,0 +1,23 @@
+package dokkuclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AppJsonReport returns app-json:report values indexed by title, i.e. "App json appjson path".
+func (c *Client) AppJsonReport(ctx context.Context, appName string) (report map[string]string, err error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("app-json:report %s", appName))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseReport(stdout), nil
+}
+
+// AppJsonSet sets app-json property for app. Empty value resets property to default.
+func (c *Client) AppJsonSet(ctx context.Context, appName string, property string, value string) error {
+	_, _, err := c.RunQuiet(ctx, strings.TrimSpace(fmt.Sprintf("app-json:set %s %s %s", appName, property, value)))
+	return err
+}
@@ -26,3 +26,36 @@ func (c *Client) AppDestroy(ctx context.Context, appName string) error {
 	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("apps:destroy %s --force", appName))
 	return err
 }
+
+// AppLocked reports whether app is locked for deploys.
+func (c *Client) AppLocked(ctx context.Context, appName string) (bool, error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("apps:locked %s", appName))
+	if err != nil {
+		if strings.Contains(stdout, "Deploy lock does not exist") {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// AppLock locks app for deploys. Running deploys are not affected.
+func (c *Client) AppLock(ctx context.Context, appName string) error {
+	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("apps:lock %s", appName))
+	return err
+}
+
+func (c *Client) AppUnlock(ctx context.Context, appName string) error {
+	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("apps:unlock %s", appName))
+	return err
+}
+
+// AppRename renames app keeping its settings. App is rebuilt unless skipDeploy is set.
+func (c *Client) AppRename(ctx context.Context, oldAppName string, newAppName string, skipDeploy bool) error {
+	skipDeployFlag := ""
+	if skipDeploy {
+		skipDeployFlag = "--skip-deploy "
+	}
+	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("apps:rename %s%s %s", skipDeployFlag, oldAppName, newAppName))
+	return err
+}
@@ -0,0 +1,75 @@
+package dokkuclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const buildArgPrefix = "--build-arg "
+
+func builderCommand(builder string, command string) string {
+	if builder == "" {
+		return "builder:" + command
+	}
+	return fmt.Sprintf("builder-%s:%s", builder, command)
+}
+
+// BuilderReport returns report of builder plugin, i.e. builder:report for empty builder or builder-dockerfile:report for "dockerfile".
+func (c *Client) BuilderReport(ctx context.Context, appName string, builder string) (map[string]string, error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("%s %s", builderCommand(builder, "report"), appName))
+	if err != nil {
+		return nil, err
+	}
+	return parseReport(stdout), nil
+}
+
+// BuilderSet sets property of builder plugin. Empty value resets property to default.
+func (c *Client) BuilderSet(ctx context.Context, appName string, builder string, property string, value string) error {
+	_, _, err := c.RunQuiet(ctx, strings.TrimSpace(fmt.Sprintf("%s %s %s %s", builderCommand(builder, "set"), appName, property, value)))
+	return err
+}
+
+// BuildArgs returns build args set using "--build-arg" docker options of build phase.
+func (c *Client) BuildArgs(ctx context.Context, appName string) (map[string]string, error) {
+	report, err := c.DockerOptionsReport(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]string)
+	for _, option := range report["build"] {
+		if !IsBuildArgDockerOption(option) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(option, buildArgPrefix), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		res[parts[0]] = parts[1]
+	}
+	return res, nil
+}
+
+// IsBuildArgDockerOption reports whether docker option is build arg.
+func IsBuildArgDockerOption(option string) bool {
+	return strings.HasPrefix(option, buildArgPrefix)
+}
+
+func (c *Client) BuildArgAdd(ctx context.Context, appName string, name string, value string, sensitive bool) error {
+	var sensitiveStrings []string
+	if sensitive && value != "" {
+		sensitiveStrings = append(sensitiveStrings, value)
+	}
+	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("docker-options:add %s build %s%s=%s", appName, buildArgPrefix, name, value), sensitiveStrings...)
+	return err
+}
+
+func (c *Client) BuildArgRemove(ctx context.Context, appName string, name string, value string, sensitive bool) error {
+	var sensitiveStrings []string
+	if sensitive && value != "" {
+		sensitiveStrings = append(sensitiveStrings, value)
+	}
+	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("docker-options:remove %s build %s%s=%s", appName, buildArgPrefix, name, value), sensitiveStrings...)
+	return err
+}
@@ -0,0 +1,45 @@
+package dokkuclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// BuildpacksReport returns ordered list of buildpacks and stack of app.
+func (c *Client) BuildpacksReport(ctx context.Context, appName string) (buildpacks []string, stack string, err error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("buildpacks:report %s", appName))
+	if err != nil {
+		return nil, "", err
+	}
+
+	report := parseReport(stdout)
+	for _, buildpack := range strings.Split(report["Buildpacks list"], ",") {
+		if buildpack = strings.TrimSpace(buildpack); buildpack != "" {
+			buildpacks = append(buildpacks, buildpack)
+		}
+	}
+	return buildpacks, report["Buildpacks stack"], nil
+}
+
+// BuildpacksSet replaces list of buildpacks keeping their order. Empty list clears buildpacks.
+func (c *Client) BuildpacksSet(ctx context.Context, appName string, buildpacks []string) error {
+	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("buildpacks:clear %s", appName))
+	if err != nil {
+		return err
+	}
+	// buildpacks are appended to the end of list
+	for _, buildpack := range buildpacks {
+		_, _, err := c.RunQuiet(ctx, fmt.Sprintf("buildpacks:add %s %s", appName, buildpack))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BuildpacksSetStack sets stack image used by herokuish or pack builder. Empty value resets stack to default.
+func (c *Client) BuildpacksSetStack(ctx context.Context, appName string, stack string) error {
+	_, _, err := c.RunQuiet(ctx, strings.TrimSpace(fmt.Sprintf("buildpacks:set-property %s stack %s", appName, stack)))
+	return err
+}
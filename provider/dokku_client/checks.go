@@ -6,7 +6,15 @@ import (
 	"strings"
 )
 
+// checksAllProcessTypes is used in disabled and skipped lists if checks are disabled or skipped for all process types.
+const checksAllProcessTypes = "_all_"
+
 func (c *Client) ChecksSet(ctx context.Context, appName string, status string) error {
+	return c.ChecksSetForProcesses(ctx, appName, status, nil)
+}
+
+// ChecksSetForProcesses sets checks status for provided process types. If process types are not provided then status is set for all of them.
+func (c *Client) ChecksSetForProcesses(ctx context.Context, appName string, status string, processTypes []string) error {
 	var action string
 	switch status {
 	case "enabled":
@@ -19,32 +27,66 @@ func (c *Client) ChecksSet(ctx context.Context, appName string, status string) e
 		return fmt.Errorf("Invalid status value. Valid values are: enabled, disabled, skipped")
 	}
 
-	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("checks:%s %s", action, appName))
+	_, _, err := c.RunQuiet(ctx, strings.TrimSpace(fmt.Sprintf("checks:%s %s %s", action, appName, strings.Join(processTypes, ","))))
 	return err
 }
 
-func (c *Client) ChecksGet(ctx context.Context, appName string) (status string, err error) {
+// ChecksSetProperty sets checks property, i.e. wait-to-retire. Empty value resets property to default.
+func (c *Client) ChecksSetProperty(ctx context.Context, appName string, property string, value string) error {
+	_, _, err := c.RunQuiet(ctx, strings.TrimSpace(fmt.Sprintf("checks:set %s %s %s", appName, property, value)))
+	return err
+}
+
+type ChecksReport struct {
+	// Status is "disabled" or "skipped" if checks are disabled or skipped for all process types, "enabled" otherwise
+	Status string
+	// Process types checks are disabled for
+	Disabled []string
+	// Process types checks are skipped for
+	Skipped      []string
+	WaitToRetire string
+}
+
+func (c *Client) ChecksReport(ctx context.Context, appName string) (res ChecksReport, err error) {
 	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("checks:report %s", appName))
 	if err != nil {
-		return "", err
+		return res, err
+	}
+	return parseChecksReport(stdout), nil
+}
+
+func parseChecksReport(stdout string) (res ChecksReport) {
+	report := parseReport(stdout)
+	res.Status = "enabled"
+	res.Disabled, res.Skipped = parseChecksList(report["Checks disabled list"]), parseChecksList(report["Checks skipped list"])
+	if len(res.Disabled) == 1 && res.Disabled[0] == checksAllProcessTypes {
+		res.Status = "disabled"
+		res.Disabled = nil
+	}
+	if len(res.Skipped) == 1 && res.Skipped[0] == checksAllProcessTypes {
+		res.Status = "skipped"
+		res.Skipped = nil
 	}
+	res.WaitToRetire = report["Checks wait to retire"]
+	return
+}
 
-	lines := strings.Split(stdout, "\n")
-	for _, line := range lines {
-		parts := strings.Split(line, ":")
-		title := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		switch title {
-		case "Checks disabled list":
-			if value == "_all_" {
-				return "disabled", nil
-			}
-		case "Checks skipped list":
-			if value == "_all_" {
-				return "skipped", nil
-			}
+func parseChecksList(value string) (processTypes []string) {
+	if value == "none" {
+		return nil
+	}
+	for _, processType := range strings.Split(value, ",") {
+		if processType = strings.TrimSpace(processType); processType != "" {
+			processTypes = append(processTypes, processType)
 		}
 	}
-	return "enabled", nil
+	return
+}
+
+func (c *Client) ChecksGet(ctx context.Context, appName string) (status string, err error) {
+	report, err := c.ChecksReport(ctx, appName)
+	if err != nil {
+		return "", err
+	}
+	return report.Status, nil
 }
@@ -0,0 +1,57 @@
+package dokkuclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChecksReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+		want   ChecksReport
+	}{
+		{
+			name: "enabled",
+			stdout: `=====> node-js-app checks information
+       Checks disabled list:          none
+       Checks skipped list:           none
+       Checks computed wait to retire: 60
+       Checks global wait to retire:  60
+       Checks wait to retire:`,
+			want: ChecksReport{Status: "enabled"},
+		},
+		{
+			name: "disabled for all",
+			stdout: `=====> node-js-app checks information
+       Checks disabled list:          _all_
+       Checks skipped list:           none
+       Checks wait to retire:         30`,
+			want: ChecksReport{Status: "disabled", WaitToRetire: "30"},
+		},
+		{
+			name: "skipped for all",
+			stdout: `=====> node-js-app checks information
+       Checks disabled list:          none
+       Checks skipped list:           _all_
+       Checks wait to retire:`,
+			want: ChecksReport{Status: "skipped"},
+		},
+		{
+			name: "per process types",
+			stdout: `=====> node-js-app checks information
+       Checks disabled list:          web,worker
+       Checks skipped list:           cron
+       Checks wait to retire:`,
+			want: ChecksReport{Status: "enabled", Disabled: []string{"web", "worker"}, Skipped: []string{"cron"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseChecksReport(tt.stdout)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
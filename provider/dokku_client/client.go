@@ -4,23 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/melbahja/goph"
 )
 
-func New(client *goph.Client, logSshCommands bool, uploadAppName string, uploadSplitBytes int) *Client {
+func New(client *goph.Client, logSshCommands bool, uploadAppName string, uploadSplitBytes int, lockWaitTimeout time.Duration) *Client {
 	return &Client{
 		client:         client,
 		logSshCommands: logSshCommands,
 
 		uploadAppName:    uploadAppName,
 		uploadSplitBytes: uploadSplitBytes,
+
+		lockWaitTimeout: lockWaitTimeout,
 	}
 }
 
@@ -31,6 +36,8 @@ type Client struct {
 	uploadAppName    string
 	uploadSplitBytes int
 
+	lockWaitTimeout time.Duration
+
 	dokkuVersion semver.Version
 }
 
@@ -43,10 +50,65 @@ func (c *Client) RunQuiet(ctx context.Context, cmd string, sensitiveStrings ...s
 	return c.Run(ctx, "--quiet "+cmd, sensitiveStrings...)
 }
 
-// Run runs any ssh command
+// lockRetryInterval is interval between attempts to run command for locked app.
+const lockRetryInterval = 5 * time.Second
+
+// lockedCommands are commands that deploy or restart app, so they are blocked by deploy lock and could be safely retried.
+// Other commands (i.e. reports or non-idempotent "run") are not retried.
+var lockedCommands = []string{
+	"apps:destroy",
+	"apps:rename",
+	"config:set",
+	"config:unset",
+	"git:from-archive",
+	"git:from-image",
+	"git:load-image",
+	"git:sync",
+	"ps:rebuild",
+	"ps:restart",
+	"ps:scale",
+}
+
+// isLockedCommand reports whether command is one of lockedCommands.
+func isLockedCommand(cmd string) bool {
+	fields := strings.Fields(cmd)
+	for len(fields) > 0 && strings.HasPrefix(fields[0], "--") {
+		fields = fields[1:]
+	}
+	return len(fields) > 0 && slices.Contains(lockedCommands, fields[0])
+}
+
+// Run runs any ssh command. If command deploys or restarts app and fails because app is locked by another deploy then it is retried until lock wait timeout is reached.
 //
 // Deprecated: Use specific methods.
 func (c *Client) Run(ctx context.Context, cmd string, sensitiveStrings ...string) (stdout string, status int, err error) {
+	if !isLockedCommand(cmd) {
+		return c.run(ctx, cmd, sensitiveStrings...)
+	}
+	return c.retryLocked(ctx, func() (string, int, error) {
+		return c.run(ctx, cmd, sensitiveStrings...)
+	})
+}
+
+// retryLocked calls attempt again while it fails because app is locked by another deploy, until lock wait timeout is reached.
+func (c *Client) retryLocked(ctx context.Context, attempt func() (stdout string, status int, err error)) (stdout string, status int, err error) {
+	deadline := time.Now().Add(c.lockWaitTimeout)
+	for {
+		stdout, status, err = attempt()
+		if err == nil || !strings.Contains(stdout, "currently has a deploy lock in place") || time.Now().Add(lockRetryInterval).After(deadline) {
+			return
+		}
+
+		tflog.Info(ctx, "App is locked, waiting for lock to be released", map[string]any{"retry_interval": lockRetryInterval.String()})
+		select {
+		case <-ctx.Done():
+			return stdout, status, err
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+func (c *Client) run(ctx context.Context, cmd string, sensitiveStrings ...string) (stdout string, status int, err error) {
 	// disabling concurrent calls
 	mutex.Lock()
 	defer mutex.Unlock()
@@ -82,6 +144,67 @@ func (c *Client) Run(ctx context.Context, cmd string, sensitiveStrings ...string
 	return
 }
 
+// runWithStdin runs ssh command streaming reader returned by openStdin to its stdin, i.e. to upload archive.
+// Reader is opened again for every attempt if command is retried because app is locked.
+func (c *Client) runWithStdin(ctx context.Context, cmd string, openStdin func() (io.ReadCloser, error)) (stdout string, status int, err error) {
+	return c.retryLocked(ctx, func() (string, int, error) {
+		stdin, err := openStdin()
+		if err != nil {
+			return "", 0, err
+		}
+		defer stdin.Close()
+		return c.runSessionWithStdin(ctx, cmd, stdin)
+	})
+}
+
+func (c *Client) runSessionWithStdin(ctx context.Context, cmd string, stdin io.Reader) (stdout string, status int, err error) {
+	// disabling concurrent calls
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if c.logSshCommands {
+		tflog.Error(ctx, "SSH cmd with stdin", map[string]any{"cmd": cmd})
+	} else {
+		tflog.Debug(ctx, "SSH cmd with stdin", map[string]any{"cmd": cmd})
+	}
+
+	session, err := c.client.NewSession()
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to open ssh session: %w", err)
+	}
+	defer session.Close()
+
+	var output singleWriter
+	session.Stdin = stdin
+	session.Stdout = &output
+	session.Stderr = &output
+
+	// ssh session doesn't support context, so it is closed on cancel
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			session.Close()
+		case <-done:
+		}
+	}()
+
+	err = session.Run(cmd)
+
+	stdout = strings.TrimSuffix(output.b.String(), "\n")
+	if err != nil {
+		status = parseStatusCode(err.Error())
+		if c.logSshCommands {
+			tflog.Error(ctx, "SSH error", map[string]any{"status": status, "stdout": stdout})
+		} else {
+			tflog.Debug(ctx, "SSH error", map[string]any{"status": status, "stdout": stdout})
+		}
+		err = fmt.Errorf("Error [%d]: %s", status, stdout)
+	}
+	return
+}
+
 func parseStatusCode(str string) int {
 	re := regexp.MustCompile("^Process exited with status ([0-9]+)$")
 	found := re.FindStringSubmatch(str)
@@ -0,0 +1,25 @@
+package dokkuclient
+
+import "testing"
+
+func TestIsLockedCommand(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want bool
+	}{
+		{cmd: "--quiet config:set --no-restart app A=1", want: true},
+		{cmd: "git:from-image app nginx:latest", want: true},
+		{cmd: "--quiet ps:scale --skip-deploy app web=1", want: true},
+		{cmd: "--quiet ps:report app", want: false},
+		{cmd: "--quiet config:export app", want: false},
+		{cmd: "run app rake db:migrate", want: false},
+		{cmd: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cmd, func(t *testing.T) {
+			if got := isLockedCommand(tt.cmd); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -34,3 +34,18 @@ func (c *Client) ConfigUnset(ctx context.Context, appName string, names []string
 	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("config:unset --no-restart %s %s", appName, strings.Join(names, " ")))
 	return err
 }
+
+// GlobalConfigExport returns global config, that is applied to all apps.
+func (c *Client) GlobalConfigExport(ctx context.Context) (res map[string]string, err error) {
+	return c.ConfigExport(ctx, "--global")
+}
+
+// GlobalConfigSet sets global config. Apps are not restarted, so it is applied on next deploy or restart.
+func (c *Client) GlobalConfigSet(ctx context.Context, data map[string]string) error {
+	return c.ConfigSet(ctx, "--global", data)
+}
+
+// GlobalConfigUnset removes keys from global config. Apps are not restarted, so it is applied on next deploy or restart.
+func (c *Client) GlobalConfigUnset(ctx context.Context, names []string) error {
+	return c.ConfigUnset(ctx, "--global", names)
+}
@@ -0,0 +1,41 @@
+package dokkuclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type CronTask struct {
+	ID       string `json:"id"`
+	Schedule string `json:"schedule"`
+	Command  string `json:"command"`
+}
+
+// CronList returns cron tasks of app, that are defined in app.json of deployed app.
+func (c *Client) CronList(ctx context.Context, appName string) (res []CronTask, err error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("cron:list %s --format json", appName))
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(stdout) == "" {
+		return nil, nil
+	}
+
+	err = json.Unmarshal([]byte(stdout), &res)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// CronRun runs cron task of app immediately. Status is exit status of task.
+// If detached then task is run in background and only status of container start is returned.
+func (c *Client) CronRun(ctx context.Context, appName string, id string, detached bool, sensitiveStrings ...string) (stdout string, status int, err error) {
+	detachFlag := ""
+	if detached {
+		detachFlag = " --detach"
+	}
+	return c.RunQuiet(ctx, fmt.Sprintf("cron:run %s %s%s", appName, id, detachFlag), sensitiveStrings...)
+}
@@ -2,8 +2,14 @@ package dokkuclient
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func (c *Client) DeployUnsetSourceImage(ctx context.Context, appName string) error {
@@ -39,7 +45,85 @@ func (c *Client) DeployFromImage(ctx context.Context, appName string, dockerImag
 	return true, nil
 }
 
+// DeployFromImageArchive uploads local archive created by "docker save" and deploys dockerImage from it using git:load-image.
+func (c *Client) DeployFromImageArchive(ctx context.Context, appName string, archivePath string, dockerImage string, allowRebuild bool) (deployed bool, err error) {
+	stdout, _, err := c.runWithStdin(ctx, fmt.Sprintf("git:load-image %s %s", appName, dockerImage), func() (io.ReadCloser, error) {
+		archive, err := os.Open(archivePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open docker image archive: %w", err)
+		}
+		return archive, nil
+	})
+	if err != nil {
+		if strings.Contains(stdout, "No changes detected, skipping git commit") {
+			if allowRebuild {
+				return true, c.DeployRebuild(ctx, appName)
+			}
+			return false, nil
+		}
+
+		return false, err
+	}
+	return true, nil
+}
+
+// DockerImageArchiveHash returns sha256 hash of local archive created by "docker save".
+func DockerImageArchiveHash(archivePath string) (string, error) {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return "", fmt.Errorf("unable to open docker image archive: %w", err)
+	}
+	defer archive.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, archive); err != nil {
+		return "", fmt.Errorf("unable to read docker image archive: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 func (c *Client) DeploySyncRepository(ctx context.Context, appName string, repositoryUrl string, ref string) error {
 	_, _, err := c.Run(ctx, fmt.Sprintf("git:sync --build %s %s %s", appName, repositoryUrl, ref))
 	return err
 }
+
+type DeployInfo struct {
+	SourceImage   string
+	GitSha        string
+	LastUpdatedAt string
+}
+
+// DeployReport returns information about deployed revision from git:report.
+// LastUpdatedAt is formatted as RFC3339 if it is provided as unix timestamp.
+func (c *Client) DeployReport(ctx context.Context, appName string) (info DeployInfo, err error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("git:report %s", appName))
+	if err != nil {
+		return info, err
+	}
+	return parseDeployReport(stdout), nil
+}
+
+func parseDeployReport(stdout string) (info DeployInfo) {
+	lines := strings.Split(stdout, "\n")
+	for _, line := range lines {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		title := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		switch title {
+		case "Git source image":
+			info.SourceImage = value
+		case "Git sha":
+			info.GitSha = value
+		case "Git last updated at":
+			if timestamp, err := strconv.ParseInt(value, 10, 64); err == nil {
+				value = time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+			}
+			info.LastUpdatedAt = value
+		}
+	}
+	return
+}
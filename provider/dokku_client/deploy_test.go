@@ -0,0 +1,47 @@
+package dokkuclient
+
+import "testing"
+
+func TestParseDeployReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+		want   DeployInfo
+	}{
+		{
+			name: "git push",
+			stdout: `=====> node-js-app git information
+       Git deploy branch:             master
+       Git global deploy branch:      master
+       Git keep git dir:              false
+       Git rev env var:               GIT_REV
+       Git sha:                       a1b2c3d
+       Git source image:
+       Git last updated at:           1700000000`,
+			want: DeployInfo{GitSha: "a1b2c3d", LastUpdatedAt: "2023-11-14T22:13:20Z"},
+		},
+		{
+			name: "image",
+			stdout: `=====> node-js-app git information
+       Git deploy branch:             master
+       Git sha:
+       Git source image:              nginx:1.25
+       Git last updated at:`,
+			want: DeployInfo{SourceImage: "nginx:1.25"},
+		},
+		{
+			name: "non-numeric last updated at",
+			stdout: `=====> node-js-app git information
+       Git last updated at:           yesterday`,
+			want: DeployInfo{LastUpdatedAt: "yesterday"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDeployReport(tt.stdout)
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,30 +3,62 @@ package dokkuclient
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 )
 
-func (c *Client) DockerOptionExists(ctx context.Context, appName string, phase string, value string) (bool, error) {
+var DockerOptionsPhases = []string{"build", "deploy", "run"}
+
+// DockerOptionsReport returns docker options indexed by phase.
+// Report contains options of phase joined with space, so they are split to separate options by tokens starting with "-".
+func (c *Client) DockerOptionsReport(ctx context.Context, appName string) (map[string][]string, error) {
 	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("docker-options:report %s", appName))
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	return parseDockerOptionsReport(stdout), nil
+}
 
-	expectedName := fmt.Sprintf("Docker options %s", phase)
+func parseDockerOptionsReport(stdout string) map[string][]string {
+	res := make(map[string][]string)
 	lines := strings.Split(stdout, "\n")
 	for _, line := range lines {
 		parts := strings.SplitN(line, ":", 2)
-		name := strings.TrimSpace(parts[0])
-		if expectedName != name {
+		if len(parts) != 2 {
 			continue
 		}
-		existingOptions := strings.TrimSpace(parts[1])
-		if !strings.Contains(existingOptions, value) {
-			return false, nil
+		name := strings.TrimSpace(parts[0])
+		for _, phase := range DockerOptionsPhases {
+			if name == fmt.Sprintf("Docker options %s", phase) {
+				res[phase] = splitDockerOptions(parts[1])
+			}
+		}
+	}
+	return res
+}
+
+func splitDockerOptions(value string) (options []string) {
+	for _, field := range strings.Fields(value) {
+		if strings.HasPrefix(field, "-") || len(options) == 0 {
+			options = append(options, field)
+		} else {
+			options[len(options)-1] += " " + field
 		}
-		return true, nil
 	}
-	return false, nil
+	return
+}
+
+// NormalizeDockerOption returns option in the same format as it is returned from DockerOptionsReport.
+func NormalizeDockerOption(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func (c *Client) DockerOptionExists(ctx context.Context, appName string, phase string, value string) (bool, error) {
+	report, err := c.DockerOptionsReport(ctx, appName)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(report[phase], NormalizeDockerOption(value)), nil
 }
 
 func (c *Client) DockerOptionAdd(ctx context.Context, appName string, phases []string, value string) error {
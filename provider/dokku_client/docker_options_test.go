@@ -0,0 +1,76 @@
+package dokkuclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDockerOptionsReport(t *testing.T) {
+	stdout := `=====> node-js-app docker options information
+       Docker options build:
+       Docker options deploy:         -v /var/lib/dokku/data/storage/node-js-app:/app/storage --restart=on-failure:10
+       Docker options run:            -v /var/lib/dokku/data/storage/node-js-app:/app/storage --label com.example=a b`
+	want := map[string][]string{
+		"build": nil,
+		"deploy": {
+			"-v /var/lib/dokku/data/storage/node-js-app:/app/storage",
+			"--restart=on-failure:10",
+		},
+		"run": {
+			"-v /var/lib/dokku/data/storage/node-js-app:/app/storage",
+			"--label com.example=a b",
+		},
+	}
+	got := parseDockerOptionsReport(stdout)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSplitDockerOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{
+			name:  "empty",
+			value: "  ",
+			want:  nil,
+		},
+		{
+			name:  "flags with values",
+			value: " --restart=on-failure:10 -v /a:/b   --link db:db ",
+			want:  []string{"--restart=on-failure:10", "-v /a:/b", "--link db:db"},
+		},
+		{
+			name:  "leading value",
+			value: "value --flag",
+			want:  []string{"value", "--flag"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitDockerOptions(tt.value)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeDockerOption(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "-v /a:/b", want: "-v /a:/b"},
+		{value: "  -v\t/a:/b  ", want: "-v /a:/b"},
+		{value: "--label  a=1\n", want: "--label a=1"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeDockerOption(tt.value); got != tt.want {
+			t.Errorf("NormalizeDockerOption(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package dokkuclient
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 )
 
 type ValueString interface {
@@ -22,3 +23,17 @@ func randStringBytes(n int) string {
 	}
 	return string(b)
 }
+
+// parseReport parses output of *:report commands to values indexed by title, i.e. "Builder selected".
+func parseReport(stdout string) map[string]string {
+	report := make(map[string]string)
+	lines := strings.Split(stdout, "\n")
+	for _, line := range lines {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		report[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return report
+}
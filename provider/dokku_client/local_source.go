@@ -0,0 +1,279 @@
+package dokkuclient
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DeployFromLocalSource deploys app from local directory or archive using git:from-archive reading archive from stdin.
+// Directory is packed to tar archive honouring .dockerignore file.
+func (c *Client) DeployFromLocalSource(ctx context.Context, appName string, sourcePath string, archiveType string) error {
+	info, err := os.Stat(sourcePath)
+	if err != nil {
+		return fmt.Errorf("unable to read local source: %w", err)
+	}
+
+	if info.IsDir() || archiveType == "" {
+		archiveType = "tar"
+	}
+	openArchive := func() (io.ReadCloser, error) {
+		if info.IsDir() {
+			pReader, pWriter := io.Pipe()
+			go func() {
+				pWriter.CloseWithError(makeLocalSourceArchive(sourcePath, pWriter))
+			}()
+			return pReader, nil
+		}
+		file, err := os.Open(sourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open local source archive: %w", err)
+		}
+		return file, nil
+	}
+
+	// "--" means that archive is read from stdin
+	_, _, err = c.runWithStdin(ctx, fmt.Sprintf("git:from-archive --archive-type %s %s --", archiveType, appName), openArchive)
+	return err
+}
+
+// LocalSourceHash returns sha256 hash of local directory content (honouring .dockerignore file) or of local archive.
+func LocalSourceHash(sourcePath string) (string, error) {
+	info, err := os.Stat(sourcePath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read local source: %w", err)
+	}
+
+	hash := sha256.New()
+	if !info.IsDir() {
+		file, err := os.Open(sourcePath)
+		if err != nil {
+			return "", fmt.Errorf("unable to open local source archive: %w", err)
+		}
+		defer file.Close()
+		if _, err := io.Copy(hash, file); err != nil {
+			return "", fmt.Errorf("unable to read local source archive: %w", err)
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	err = walkLocalSource(sourcePath, func(relPath string, absPath string, entry fs.DirEntry) error {
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s\x00%o\x00", relPath, info.Mode())
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(absPath)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(hash, "%s\x00", link)
+		case info.Mode().IsRegular():
+			file, err := os.Open(absPath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			if _, err := io.Copy(hash, file); err != nil {
+				return err
+			}
+			hash.Write([]byte{0})
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to calculate local source hash: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func makeLocalSourceArchive(sourceDirectory string, writer io.Writer) error {
+	tarWriter := tar.NewWriter(writer)
+
+	err := walkLocalSource(sourceDirectory, func(relPath string, absPath string, entry fs.DirEntry) error {
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			link, err = os.Readlink(absPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = relPath
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			file, err := os.Open(absPath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			if _, err := io.Copy(tarWriter, file); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to make tar archive: %w", err)
+	}
+	return tarWriter.Close()
+}
+
+// walkLocalSource calls callback for every entry of directory (in lexical order) that is not excluded by .dockerignore file.
+// Relative paths are slash-separated.
+func walkLocalSource(sourceDirectory string, callback func(relPath string, absPath string, entry fs.DirEntry) error) error {
+	ignore, err := readDockerignore(filepath.Join(sourceDirectory, ".dockerignore"))
+	if err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(sourceDirectory, func(absPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(sourceDirectory, absPath)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if ignore.excludes(relPath) {
+			// files of excluded directory could be included back only by negated pattern
+			if entry.IsDir() && !ignore.hasNegations {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		return callback(relPath, absPath, entry)
+	})
+}
+
+type dockerignoreRule struct {
+	re     *regexp.Regexp
+	negate bool
+}
+
+type dockerignore struct {
+	rules        []dockerignoreRule
+	hasNegations bool
+}
+
+// readDockerignore parses .dockerignore file. Missing file means that nothing is excluded.
+// https://docs.docker.com/build/concepts/context/#dockerignore-files
+func readDockerignore(filePath string) (res dockerignore, err error) {
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return res, fmt.Errorf("unable to read .dockerignore: %w", err)
+	}
+	defer file.Close()
+	return parseDockerignore(file)
+}
+
+func parseDockerignore(r io.Reader) (res dockerignore, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		rule := dockerignoreRule{}
+		if strings.HasPrefix(pattern, "!") {
+			rule.negate = true
+			res.hasNegations = true
+			pattern = strings.TrimSpace(pattern[1:])
+		}
+		pattern = strings.Trim(filepath.ToSlash(filepath.Clean(pattern)), "/")
+		if pattern == "." || pattern == "" {
+			continue
+		}
+		rule.re, err = dockerignorePatternToRegexp(pattern)
+		if err != nil {
+			return res, fmt.Errorf("invalid .dockerignore pattern %q: %w", pattern, err)
+		}
+		res.rules = append(res.rules, rule)
+	}
+	return res, scanner.Err()
+}
+
+// excludes reports whether path is excluded. Pattern matches path itself or any of its parent directories, last matched rule wins.
+func (d dockerignore) excludes(relPath string) bool {
+	excluded := false
+	for _, rule := range d.rules {
+		matched := false
+		for p := relPath; p != "."; p = filepath.ToSlash(filepath.Dir(p)) {
+			if rule.re.MatchString(p) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			excluded = !rule.negate
+		}
+	}
+	return excluded
+}
+
+func dockerignorePatternToRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch ch {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				// "**/" matches zero or more directories
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,97 @@
+package dokkuclient
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDockerignoreExcludes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		paths   map[string]bool
+	}{
+		{
+			name:    "empty",
+			content: "",
+			paths:   map[string]bool{"main.go": false, "node_modules/a.js": false},
+		},
+		{
+			name:    "plain names and parent directories",
+			content: "node_modules\n/.git/\n./tmp",
+			paths: map[string]bool{
+				"node_modules":        true,
+				"node_modules/a/b.js": true,
+				"src/node_modules":    false,
+				".git/HEAD":           true,
+				"tmp/cache":           true,
+				"node_modules_backup": false,
+				"src/main.go":         false,
+			},
+		},
+		{
+			name:    "single star",
+			content: "*.log\ndocs/*.md",
+			paths: map[string]bool{
+				"app.log":        true,
+				"logs/app.log":   false,
+				"docs/README.md": true,
+				"docs/a/b.md":    false,
+				"README.md":      false,
+			},
+		},
+		{
+			name:    "double star",
+			content: "**/*.log\ncache/**",
+			paths: map[string]bool{
+				"app.log":        true,
+				"logs/a/app.log": true,
+				"cache/a/b":      true,
+				"cache":          false,
+				"src/cache/a":    false,
+			},
+		},
+		{
+			name:    "question mark",
+			content: "file?.txt",
+			paths: map[string]bool{
+				"file1.txt":  true,
+				"file12.txt": false,
+				"file/.txt":  false,
+			},
+		},
+		{
+			name:    "negation",
+			content: "*.md\n!README.md\nbuild\n!build/keep",
+			paths: map[string]bool{
+				"CHANGELOG.md": true,
+				"README.md":    false,
+				"build/out":    true,
+				"build/keep":   false,
+				"build/keep/a": false,
+			},
+		},
+		{
+			name:    "comments and blank lines",
+			content: "# *.go\n\n   \n  secret.txt  \n\\#notes",
+			paths: map[string]bool{
+				"main.go":    false,
+				"secret.txt": true,
+				"#notes":     true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ignore, err := parseDockerignore(strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for relPath, want := range tt.paths {
+				if got := ignore.excludes(relPath); got != want {
+					t.Errorf("excludes(%q) = %v, want %v", relPath, got, want)
+				}
+			}
+		})
+	}
+}
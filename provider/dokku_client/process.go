@@ -3,9 +3,91 @@ package dokkuclient
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 func (c *Client) ProcessRestart(ctx context.Context, appName string) error {
 	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("ps:restart %s", appName))
 	return err
 }
+
+func (c *Client) ProcessIsDeployed(ctx context.Context, appName string) (bool, error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("ps:report %s --deployed", appName))
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(stdout) == "true", nil
+}
+
+// ProcessRestartIfDeployed restarts app only if it was already deployed, so changed settings will be applied.
+func (c *Client) ProcessRestartIfDeployed(ctx context.Context, appName string) error {
+	deployed, err := c.ProcessIsDeployed(ctx, appName)
+	if err != nil {
+		return err
+	}
+	if !deployed {
+		return nil
+	}
+	return c.ProcessRestart(ctx, appName)
+}
+
+// ProcessReport returns ps:report values indexed by title, i.e. "Ps restart policy".
+func (c *Client) ProcessReport(ctx context.Context, appName string) (report map[string]string, err error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("ps:report %s", appName))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseReport(stdout), nil
+}
+
+// ProcessSet sets ps property for app. Empty value resets property to default.
+func (c *Client) ProcessSet(ctx context.Context, appName string, property string, value string) error {
+	_, _, err := c.RunQuiet(ctx, strings.TrimSpace(fmt.Sprintf("ps:set %s %s %s", appName, property, value)))
+	return err
+}
+
+// ProcessScaleGet returns configured count of containers for each process type, including changes not deployed yet.
+// ps:report lists only running containers, so scale is read from ps:scale.
+func (c *Client) ProcessScaleGet(ctx context.Context, appName string) (scale map[string]int64, err error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("ps:scale %s", appName))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProcessScale(stdout), nil
+}
+
+// parseProcessScale parses output of ps:scale, i.e. "web:  1".
+// Header lines ("proctype: qty", "--------: ---") are skipped because count is not a number.
+func parseProcessScale(stdout string) map[string]int64 {
+	scale := make(map[string]int64)
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		// older versions of dokku prefix every line with arrow
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(line, "----->"), "=====>"))
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		count, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		scale[strings.TrimSuffix(fields[0], ":")] = count
+	}
+	return scale
+}
+
+// ProcessScaleSet sets count of containers for process types without triggering deploy.
+func (c *Client) ProcessScaleSet(ctx context.Context, appName string, scale map[string]int64) error {
+	var args []string
+	for processType, count := range scale {
+		args = append(args, fmt.Sprintf("%s=%d", processType, count))
+	}
+	sort.Strings(args)
+	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("ps:scale --skip-deploy %s %s", appName, strings.Join(args, " ")))
+	return err
+}
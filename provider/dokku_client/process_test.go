@@ -0,0 +1,57 @@
+package dokkuclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProcessScale(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+		want   map[string]int64
+	}{
+		{
+			name: "current format",
+			stdout: `-----> Scaling for node-js-app
+proctype: qty
+--------: ---
+web:  2
+worker:  1
+`,
+			want: map[string]int64{"web": 2, "worker": 1},
+		},
+		{
+			name: "quiet",
+			stdout: `proctype: qty
+--------: ---
+web:  1
+release-worker:  0
+`,
+			want: map[string]int64{"web": 1, "release-worker": 0},
+		},
+		{
+			name: "legacy format",
+			stdout: `-----> Scaling for python-app
+-----> proctype           qty
+-----> --------           ---
+-----> web                3
+-----> worker             1
+`,
+			want: map[string]int64{"web": 3, "worker": 1},
+		},
+		{
+			name:   "empty",
+			stdout: "",
+			want:   map[string]int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseProcessScale(tt.stdout)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
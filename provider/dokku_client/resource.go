@@ -0,0 +1,127 @@
+package dokkuclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ResourceDefaultProcessType is used by dokku for resources applied to all process types.
+const ResourceDefaultProcessType = "_default_"
+
+type ResourceValues struct {
+	Cpu        string
+	Memory     string
+	MemorySwap string
+	NvidiaGpu  string
+}
+
+func (v ResourceValues) IsEmpty() bool {
+	return v.Cpu == "" && v.Memory == "" && v.MemorySwap == "" && v.NvidiaGpu == ""
+}
+
+type ProcessResources struct {
+	Limit   ResourceValues
+	Reserve ResourceValues
+}
+
+// ResourcesReport returns resource limits and reservations indexed by process type.
+func (c *Client) ResourcesReport(ctx context.Context, appName string) (res map[string]*ProcessResources, err error) {
+	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("resource:report %s", appName))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResourcesReport(stdout), nil
+}
+
+// parseResourcesReport parses output of resource:report, i.e. "web limit memory swap: 0".
+func parseResourcesReport(stdout string) map[string]*ProcessResources {
+	res := make(map[string]*ProcessResources)
+	lines := strings.Split(stdout, "\n")
+	for _, line := range lines {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(parts[1])
+		fields := strings.Fields(parts[0])
+		if len(fields) < 3 {
+			continue
+		}
+		processType := fields[0]
+		kind := fields[1]
+		name := strings.Join(fields[2:], "-")
+
+		var values *ResourceValues
+		processResources, ok := res[processType]
+		if !ok {
+			processResources = &ProcessResources{}
+		}
+		switch kind {
+		case "limit":
+			values = &processResources.Limit
+		// reservations are reported as "reservation", but set using resource:reserve
+		case "reserve", "reservation":
+			values = &processResources.Reserve
+		default:
+			continue
+		}
+		switch name {
+		case "cpu":
+			values.Cpu = value
+		case "memory":
+			values.Memory = value
+		case "memory-swap":
+			values.MemorySwap = value
+		case "nvidia-gpu":
+			values.NvidiaGpu = value
+		default:
+			continue
+		}
+		res[processType] = processResources
+	}
+	return res
+}
+
+// ResourceLimitSet replaces resource limits for process type.
+func (c *Client) ResourceLimitSet(ctx context.Context, appName string, processType string, values ResourceValues) error {
+	return c.resourceSet(ctx, "limit", appName, processType, values)
+}
+
+// ResourceReserveSet replaces resource reservations for process type.
+func (c *Client) ResourceReserveSet(ctx context.Context, appName string, processType string, values ResourceValues) error {
+	return c.resourceSet(ctx, "reserve", appName, processType, values)
+}
+
+func (c *Client) resourceSet(ctx context.Context, kind string, appName string, processType string, values ResourceValues) error {
+	processTypeFlag := ""
+	if processType != ResourceDefaultProcessType {
+		processTypeFlag = fmt.Sprintf("--process-type %s ", processType)
+	}
+
+	// resource:limit and resource:reserve change only provided values, so clear previous ones first
+	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("resource:%s-clear %s%s", kind, processTypeFlag, appName))
+	if err != nil {
+		return err
+	}
+	if values.IsEmpty() {
+		return nil
+	}
+
+	var flags []string
+	if values.Cpu != "" {
+		flags = append(flags, "--cpu "+values.Cpu)
+	}
+	if values.Memory != "" {
+		flags = append(flags, "--memory "+values.Memory)
+	}
+	if values.MemorySwap != "" {
+		flags = append(flags, "--memory-swap "+values.MemorySwap)
+	}
+	if values.NvidiaGpu != "" {
+		flags = append(flags, "--nvidia-gpu "+values.NvidiaGpu)
+	}
+	_, _, err = c.RunQuiet(ctx, fmt.Sprintf("resource:%s %s%s %s", kind, processTypeFlag, strings.Join(flags, " "), appName))
+	return err
+}
@@ -0,0 +1,70 @@
+package dokkuclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseResourcesReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+		want   map[string]*ProcessResources
+	}{
+		{
+			name: "limits and reservations",
+			stdout: `=====> node-js-app resource information
+       _default_ limit cpu:           1
+       _default_ limit memory:        512m
+       _default_ limit memory swap:
+       _default_ limit network:
+       _default_ limit network ingress:
+       _default_ limit network egress:
+       _default_ limit nvidia gpu:
+       _default_ reservation cpu:
+       _default_ reservation memory:  256m
+       _default_ reservation memory swap:
+       _default_ reservation network:
+       _default_ reservation network ingress:
+       _default_ reservation network egress:
+       _default_ reservation nvidia gpu:
+       web limit cpu:
+       web limit memory:              1g
+       web limit memory swap:         0
+       web limit network:
+       web limit network ingress:
+       web limit network egress:
+       web limit nvidia gpu:          1
+       web reservation cpu:           0.5
+       web reservation memory:
+       web reservation memory swap:
+       web reservation network:
+       web reservation network ingress:
+       web reservation network egress:
+       web reservation nvidia gpu:`,
+			want: map[string]*ProcessResources{
+				"_default_": {
+					Limit:   ResourceValues{Cpu: "1", Memory: "512m"},
+					Reserve: ResourceValues{Memory: "256m"},
+				},
+				"web": {
+					Limit:   ResourceValues{Memory: "1g", MemorySwap: "0", NvidiaGpu: "1"},
+					Reserve: ResourceValues{Cpu: "0.5"},
+				},
+			},
+		},
+		{
+			name:   "empty",
+			stdout: "=====> node-js-app resource information",
+			want:   map[string]*ProcessResources{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseResourcesReport(tt.stdout)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package dokkuclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// AppRun runs one-off command in new container of app. Status is exit status of command.
+// If detached then command is run in background and only status of container start is returned.
+func (c *Client) AppRun(ctx context.Context, appName string, command string, detached bool, sensitiveStrings ...string) (stdout string, status int, err error) {
+	subcommand := "run"
+	if detached {
+		subcommand = "run:detached"
+	}
+	return c.RunQuiet(ctx, fmt.Sprintf("%s %s %s", subcommand, appName, command), sensitiveStrings...)
+}
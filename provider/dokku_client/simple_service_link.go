@@ -3,9 +3,28 @@ package dokkuclient
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 )
 
+// simpleServicePluginNames are plugins which links are managed by service link resources
+var simpleServicePluginNames = []string{"clickhouse", "couchdb", "elasticsearch", "mariadb", "mongo", "mysql", "nats", "postgres", "rabbitmq", "redis", "rethinkdb"}
+
+// IsSimpleServiceLinkURL reports whether value is url of service, that is set to app config on service link (i.e. DATABASE_URL).
+// Service plugins use "dokku-<plugin>-<service>" as host of service url.
+func IsSimpleServiceLinkURL(value string) bool {
+	serviceURL, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	for _, pluginName := range simpleServicePluginNames {
+		if strings.HasPrefix(serviceURL.Hostname(), "dokku-"+pluginName+"-") {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Client) SimpleServiceLinkExists(ctx context.Context, servicePluginName string, serviceName string, appName string) (bool, error) {
 	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("%s:linked %s %s", servicePluginName, serviceName, appName))
 	if err != nil {
@@ -6,11 +6,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 
@@ -20,6 +22,16 @@ import (
 
 const hostStoragePrefix = "/var/lib/dokku/data/storage/"
 
+var storageNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// validateStorageName checks that name of storage directory could be safely used in commands run in storage root.
+func validateStorageName(name string) error {
+	if !storageNameRe.MatchString(name) {
+		return fmt.Errorf("invalid storage name %q", name)
+	}
+	return nil
+}
+
 func (c *Client) StorageExport(ctx context.Context, appName string) (res map[string]string, err error) {
 	stdout, _, err := c.RunQuiet(ctx, fmt.Sprintf("storage:list %s", appName))
 	if err != nil {
@@ -66,14 +78,88 @@ func (c *Client) StorageUnmount(ctx context.Context, appName string, name string
 	return err
 }
 
+// StorageHostPath returns absolute path on host for storage with provided name
+func StorageHostPath(name string) string {
+	return getPathToMount(name)
+}
+
 func (c *Client) storageEnsureDirectory(ctx context.Context, name string) error {
 	if name != "" && name[0] != '/' {
-		_, _, err := c.RunQuiet(ctx, fmt.Sprintf("storage:ensure-directory %s", name))
+		return c.StorageEnsureDirectory(ctx, name, "")
+	}
+	return nil
+}
+
+func (c *Client) StorageEnsureDirectory(ctx context.Context, name string, chown string) error {
+	if chown != "" {
+		chown = fmt.Sprintf("--chown %s", chown)
+	}
+	_, _, err := c.RunQuiet(ctx, fmt.Sprintf("storage:ensure-directory %s %s", chown, name))
+	return err
+}
+
+// ErrHostShellUnavailable is returned by checks that are run directly on host if ssh user is restricted to dokku commands.
+var ErrHostShellUnavailable = errors.New("shell commands are not available for ssh user, only dokku commands are allowed")
+
+// runOnHost runs shell command directly on host over ssh connection, so no helper app is created.
+func (c *Client) runOnHost(ctx context.Context, cmd string) (stdout string, status int, err error) {
+	stdout, status, err = c.Run(ctx, cmd)
+	if err != nil && strings.Contains(stdout, "is not a dokku command") {
+		return stdout, status, ErrHostShellUnavailable
+	}
+	return
+}
+
+// StorageDirectoryExists checks existence of storage directory on host.
+// It is checked directly on host if ssh user is allowed to run shell commands, otherwise helper app is used.
+func (c *Client) StorageDirectoryExists(ctx context.Context, name string) (exists bool, err error) {
+	err = validateStorageName(name)
+	if err != nil {
+		return false, err
+	}
+
+	stdout, status, err := c.runOnHost(ctx, fmt.Sprintf("test -d '%s'", StorageHostPath(name)))
+	if errors.Is(err, ErrHostShellUnavailable) {
+		return c.storageDirectoryExistsInHelperApp(ctx, name)
+	}
+	if err != nil {
+		if status == 1 && stdout == "" {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to check directory existence: %w", err)
+	}
+	return true, nil
+}
+
+func (c *Client) storageDirectoryExistsInHelperApp(ctx context.Context, name string) (exists bool, err error) {
+	err = c.withStorageHelperApp(ctx, hostStoragePrefix, func(appName string) error {
+		stdout, status, err := c.Run(ctx, fmt.Sprintf("run %s test -d '/mnt/%s'", appName, name))
 		if err != nil {
-			return err
+			if status == 1 {
+				return nil
+			}
+			return fmt.Errorf("unable to check directory existence: %s", stdout)
 		}
+		exists = true
+		return nil
+	})
+	return
+}
+
+func (c *Client) StorageDirectoryDestroy(ctx context.Context, name string) error {
+	// storage root is mounted to helper app, so name is checked to not point outside of storage directory
+	err := validateStorageName(name)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return c.withStorageHelperApp(ctx, hostStoragePrefix, func(appName string) error {
+		_, _, err := c.Run(ctx, fmt.Sprintf("run %s rm -rf '/mnt/%s'", appName, name))
+		if err != nil {
+			return fmt.Errorf("unable to remove directory: %w", err)
+		}
+		return nil
+	})
 }
 
 func (c *Client) StorageEnsure(ctx context.Context, name string, localDirectory *string) error {
@@ -123,7 +209,14 @@ func (c *Client) makeTarArchiveForDirectory(ctx context.Context, localDirectory
 
 		// Modify the header name to be relative to the source directory
 		relPath, _ := filepath.Rel(localDirectory, path)
+		if relPath == "." {
+			// root entry is skipped, so mode and owner of remote directory are not changed
+			return nil
+		}
 		header.Name = relPath
+		// ownership of local user has no meaning on host, so files are extracted as owned by root
+		header.Uid, header.Gid = 0, 0
+		header.Uname, header.Gname = "", ""
 
 		// Write the header to the tar archive
 		if err := tarWriter.WriteHeader(header); err != nil {
@@ -154,22 +247,29 @@ func (c *Client) makeTarArchiveForDirectory(ctx context.Context, localDirectory
 	return err
 }
 
+func (c *Client) storageSyncDirectories(ctx context.Context, storageName string, localDirectory string, remoteDirectory string) error {
+	tflog.Debug(ctx, "Uploading local directory to remote", map[string]any{"local_directory": localDirectory, "remote_directory": remoteDirectory})
+
+	return c.withStorageHelperApp(ctx, storageName, func(appName string) error {
+		// _, _, err = c.Run(ctx, fmt.Sprintf("run %s find /mnt -mindepth 1 -delete", appName))
+		// if err != nil {
+		// 	return fmt.Errorf("unable to clear mounted directory: %w", err)
+		// }
+
+		// -- copy tar archive to remote host
+		return c.copyToRemoteHost(ctx, appName, localDirectory)
+		// --
+	})
+}
+
 // / dokku apps:create <APP_NAME>
 // / dokku checks:disable <APP_NAME>
 // / dokku config:set <APP_NAME> DOKKU_DOCKERFILE_START_CMD='sleep infinity'
 // / dokku storage:mount <APP_NAME> <REMOTE_DIRECTORY>:/mnt
 // / dokku git:from-image <APP_NAME> busybox
-// / dokku enter <APP_NAME> web sh
-// /     ## in pseudo-tty
-// /     # multiple echo commands with base64-encoded tar archive
-// /     echo -n '...' >> /tmp/tmp.tar.base64
-// /     # untar archive
-// /     cat /tmp/tmp.tar.base64 | base64 -d | tar x -C /mnt
-// /     exit
+// / ## run callback with helper app name
 // / dokku apps:destroy --force <APP_NAME>
-func (c *Client) storageSyncDirectories(ctx context.Context, storageName string, localDirectory string, remoteDirectory string) error {
-	tflog.Debug(ctx, "Uploading local directory to remote", map[string]any{"local_directory": localDirectory, "remote_directory": remoteDirectory})
-
+func (c *Client) withStorageHelperApp(ctx context.Context, storageName string, callback func(appName string) error) error {
 	appName := fmt.Sprintf("%s--%s", c.uploadAppName, randStringBytes(8))
 	err := c.AppCreate(ctx, appName)
 	if err != nil {
@@ -199,22 +299,22 @@ func (c *Client) storageSyncDirectories(ctx context.Context, storageName string,
 
 	deployed, err := c.DeployFromImage(ctx, appName, "busybox", false)
 	if err != nil {
-		return fmt.Errorf("unable to deploy sync app: %w", err)
+		return fmt.Errorf("unable to deploy helper app: %w", err)
 	}
 	if !deployed {
-		return fmt.Errorf("sync app wasn't deployed")
+		return fmt.Errorf("helper app wasn't deployed")
 	}
 
-	// _, _, err = c.Run(ctx, fmt.Sprintf("run %s find /mnt -mindepth 1 -delete", appName))
-	// if err != nil {
-	// 	return fmt.Errorf("unable to clear mounted directory: %w", err)
-	// }
-
-	// -- copy tar archive to remote host
-	return c.copyToRemoteHost(ctx, appName, localDirectory)
-	// --
+	return callback(appName)
 }
 
+// / dokku enter <APP_NAME> web sh
+// /     ## in pseudo-tty
+// /     # multiple echo commands with base64-encoded tar archive
+// /     echo -n '...' >> /tmp/tmp.tar.base64
+// /     # untar archive
+// /     cat /tmp/tmp.tar.base64 | base64 -d | tar x -C /mnt
+// /     exit
 func (c *Client) copyToRemoteHost(ctx context.Context, appName string, localDirectory string) error {
 	session, err := c.client.NewSession()
 	if err != nil {
@@ -0,0 +1,217 @@
+package dokkuclient
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+)
+
+type StorageFile struct {
+	Name    string
+	Mode    int64
+	Content []byte
+}
+
+var (
+	downloadStatusRe  = regexp.MustCompile(`__TF_STATUS__(\d+)`)
+	downloadSizeRe    = regexp.MustCompile(`__TF_SIZE__(\d+)`)
+	downloadContentRe = regexp.MustCompile(`(?s)__TF_BEGIN__(.*)__TF_END__`)
+	whitespaceRe      = regexp.MustCompile(`\s+`)
+)
+
+// StorageDownload downloads file or directory from storage using helper app.
+// Directory content is returned with paths relative to remotePath, single file is returned with its base name.
+func (c *Client) StorageDownload(ctx context.Context, storageName string, remotePath string, maxSizeBytes int64) (files []StorageFile, isDirectory bool, err error) {
+	remotePath, err = storageRelativePath(remotePath)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tflog.Debug(ctx, "Downloading remote storage", map[string]any{"storage": storageName, "path": remotePath})
+
+	err = c.withStorageHelperApp(ctx, storageName, func(appName string) error {
+		output, err := c.runInHelperShell(ctx, appName, []string{
+			fmt.Sprintf("if [ -d '/mnt/%s' ]; then tar c -C '/mnt/%s' . ; else tar c -C '/mnt/%s' '%s'; fi > /tmp/tmp.tar", remotePath, remotePath, path.Dir(remotePath), path.Base(remotePath)),
+			`echo "__TF_STATUS__$?"`,
+			`echo "__TF_SIZE__$(wc -c < /tmp/tmp.tar)"`,
+			fmt.Sprintf(`if [ "$(wc -c < /tmp/tmp.tar)" -le %d ]; then echo "__TF_""BEGIN__"; base64 /tmp/tmp.tar; echo "__TF_""END__"; fi`, maxSizeBytes),
+		})
+		if err != nil {
+			return err
+		}
+
+		status := downloadStatusRe.FindStringSubmatch(output)
+		if status == nil {
+			return fmt.Errorf("unable to make tar archive: unexpected output")
+		}
+		if status[1] != "0" {
+			return fmt.Errorf("unable to make tar archive for %s: file or directory not found", remotePath)
+		}
+
+		size := downloadSizeRe.FindStringSubmatch(output)
+		if size == nil {
+			return fmt.Errorf("unable to get size of tar archive: unexpected output")
+		}
+		sizeBytes, err := strconv.ParseInt(size[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to parse size of tar archive: %w", err)
+		}
+		if sizeBytes > maxSizeBytes {
+			return fmt.Errorf("size of %s (%d bytes) exceeds limit of %d bytes", remotePath, sizeBytes, maxSizeBytes)
+		}
+
+		content := downloadContentRe.FindStringSubmatch(output)
+		if content == nil {
+			return fmt.Errorf("unable to download tar archive: unexpected output")
+		}
+		archive, err := base64.StdEncoding.DecodeString(whitespaceRe.ReplaceAllString(content[1], ""))
+		if err != nil {
+			return fmt.Errorf("unable to decode tar archive: %w", err)
+		}
+
+		files, isDirectory, err = readTarArchive(archive, maxSizeBytes)
+		return err
+	})
+	return
+}
+
+func storageRelativePath(remotePath string) (string, error) {
+	remotePath = path.Clean(remotePath)
+	if strings.HasPrefix(remotePath, "/") || remotePath == ".." || strings.HasPrefix(remotePath, "../") {
+		return "", fmt.Errorf("path must be relative to storage directory")
+	}
+	if strings.Contains(remotePath, "'") {
+		return "", fmt.Errorf("path must not contain quotes")
+	}
+	return remotePath, nil
+}
+
+// StorageFilesWriteToDirectory saves downloaded files to local directory.
+// Files with the same content and permissions are not written again.
+func StorageFilesWriteToDirectory(files []StorageFile, localDirectory string) error {
+	for _, f := range files {
+		localPath := filepath.Join(localDirectory, filepath.FromSlash(f.Name))
+		if info, err := os.Lstat(localPath); err == nil && info.Mode().IsRegular() && info.Mode().Perm() == os.FileMode(f.Mode).Perm() {
+			content, err := os.ReadFile(localPath)
+			if err == nil && bytes.Equal(content, f.Content) {
+				continue
+			}
+		}
+		err := os.MkdirAll(filepath.Dir(localPath), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("unable to create directory for %s: %w", f.Name, err)
+		}
+		err = os.WriteFile(localPath, f.Content, os.FileMode(f.Mode).Perm())
+		if err != nil {
+			return fmt.Errorf("unable to write file %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// readTarArchive returns regular files from tar archive. Other entries (i.e. symlinks) are skipped.
+// Total size of files is limited by maxSizeBytes.
+func readTarArchive(archive []byte, maxSizeBytes int64) (files []StorageFile, isDirectory bool, err error) {
+	var sizeBytes int64
+	tarReader := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to read tar archive: %w", err)
+		}
+
+		name := path.Clean(header.Name)
+		// tar archive for directory contains "." entry
+		if header.Typeflag == tar.TypeDir && name == "." {
+			isDirectory = true
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if strings.HasPrefix(name, "/") || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, false, fmt.Errorf("invalid file name in tar archive: %s", header.Name)
+		}
+		sizeBytes += header.Size
+		if sizeBytes > maxSizeBytes {
+			return nil, false, fmt.Errorf("size of files in tar archive exceeds limit of %d bytes", maxSizeBytes)
+		}
+
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to read %s from tar archive: %w", header.Name, err)
+		}
+		files = append(files, StorageFile{
+			Name:    name,
+			Mode:    header.Mode,
+			Content: content,
+		})
+	}
+	return files, isDirectory, nil
+}
+
+// runInHelperShell runs provided commands in shell of helper app and returns collected output.
+func (c *Client) runInHelperShell(ctx context.Context, appName string, commands []string) (output string, err error) {
+	session, err := c.client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("unable to open ssh session: %w", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return "", fmt.Errorf("unable to setup stdin pipe: %w", err)
+	}
+	defer stdin.Close()
+
+	var sessionStdoutCollector singleWriter
+	session.Stdout = &sessionStdoutCollector
+	session.Stderr = &sessionStdoutCollector
+
+	if err := session.RequestPty("xterm", 40, 256, ssh.TerminalModes{
+		ssh.ECHO:          0,     // disable echoing
+		ssh.TTY_OP_ISPEED: 14400, // input speed = 14.4kbaud
+		ssh.TTY_OP_OSPEED: 14400, // output speed = 14.4kbaud
+	}); err != nil {
+		return "", fmt.Errorf("request for pseudo terminal failed: %w", err)
+	}
+
+	err = session.Start(fmt.Sprintf("enter %s web sh", appName))
+	if err != nil {
+		return "", fmt.Errorf("unable to start shell: %w", err)
+	}
+
+	for _, command := range append(commands, "exit") {
+		_, err = io.WriteString(stdin, command+"\n")
+		if err != nil {
+			return "", fmt.Errorf("unable to write command: %w", err)
+		}
+	}
+
+	err = stdin.Close()
+	if err != nil {
+		return "", fmt.Errorf("unable to close stdin: %w", err)
+	}
+
+	err = session.Wait()
+	if err != nil {
+		return "", fmt.Errorf("unable to run commands: %w", err)
+	}
+
+	return sessionStdoutCollector.b.String(), nil
+}
@@ -0,0 +1,171 @@
+package dokkuclient
+
+import (
+	"archive/tar"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestStorageRelativePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: ".", want: "."},
+		{path: "dir/file.txt", want: "dir/file.txt"},
+		{path: "./dir//file.txt", want: "dir/file.txt"},
+		{path: "dir/../file.txt", want: "file.txt"},
+		{path: "/etc/passwd", wantErr: true},
+		{path: "..", wantErr: true},
+		{path: "../other", wantErr: true},
+		{path: "dir/../../other", wantErr: true},
+		{path: "it's", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := storageRelativePath(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	content  string
+}
+
+func makeTarArchive(t *testing.T, entries []tarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tarWriter := tar.NewWriter(&buf)
+	for _, e := range entries {
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0644,
+			Size:     int64(len(e.content)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tarWriter.Write([]byte(e.content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := tarWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadTarArchive(t *testing.T) {
+	tests := []struct {
+		name          string
+		entries       []tarEntry
+		maxSizeBytes  int64
+		wantFiles     []string
+		wantDirectory bool
+		wantErr       bool
+	}{
+		{
+			name:      "single file",
+			entries:   []tarEntry{{name: "file.txt", typeflag: tar.TypeReg, content: "abc"}},
+			wantFiles: []string{"file.txt"},
+		},
+		{
+			name: "directory",
+			entries: []tarEntry{
+				{name: "./", typeflag: tar.TypeDir},
+				{name: "./sub/", typeflag: tar.TypeDir},
+				{name: "./sub/file.txt", typeflag: tar.TypeReg, content: "abc"},
+			},
+			wantFiles:     []string{"sub/file.txt"},
+			wantDirectory: true,
+		},
+		{
+			name: "symlinks are skipped",
+			entries: []tarEntry{
+				{name: "./", typeflag: tar.TypeDir},
+				{name: "./passwd", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"},
+				{name: "./hard", typeflag: tar.TypeLink, linkname: "/etc/shadow"},
+				{name: "./file.txt", typeflag: tar.TypeReg, content: "abc"},
+			},
+			wantFiles:     []string{"file.txt"},
+			wantDirectory: true,
+		},
+		{
+			name:    "absolute path",
+			entries: []tarEntry{{name: "/etc/passwd", typeflag: tar.TypeReg, content: "abc"}},
+			wantErr: true,
+		},
+		{
+			name:    "path traversal",
+			entries: []tarEntry{{name: "./sub/../../passwd", typeflag: tar.TypeReg, content: "abc"}},
+			wantErr: true,
+		},
+		{
+			name: "size limit",
+			entries: []tarEntry{
+				{name: "a.txt", typeflag: tar.TypeReg, content: "abc"},
+				{name: "b.txt", typeflag: tar.TypeReg, content: "abc"},
+			},
+			maxSizeBytes: 5,
+			wantErr:      true,
+		},
+		{
+			name: "size equal to limit",
+			entries: []tarEntry{
+				{name: "a.txt", typeflag: tar.TypeReg, content: "abc"},
+				{name: "b.txt", typeflag: tar.TypeReg, content: "abc"},
+			},
+			maxSizeBytes: 6,
+			wantFiles:    []string{"a.txt", "b.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxSizeBytes := tt.maxSizeBytes
+			if maxSizeBytes == 0 {
+				maxSizeBytes = 1024
+			}
+			files, isDirectory, err := readTarArchive(makeTarArchive(t, tt.entries), maxSizeBytes)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", files)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var names []string
+			for _, f := range files {
+				names = append(names, f.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantFiles) {
+				t.Errorf("got files %q, want %q", names, tt.wantFiles)
+			}
+			if isDirectory != tt.wantDirectory {
+				t.Errorf("got isDirectory %v, want %v", isDirectory, tt.wantDirectory)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package dokkuclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+)
+
+var (
+	storageFileChecksumRe = regexp.MustCompile(`([0-9a-f]{64})\s+/`)
+	storageFileModeRe     = regexp.MustCompile(`__TF_MODE__([0-7]+)`)
+)
+
+// StorageFileUpload uploads single file to storage.
+// File is placed to temporary directory and synchronized the same way as storage.local_directory.
+// Storage directory is not ensured again, so ownership set by dokku_storage is kept.
+func (c *Client) StorageFileUpload(ctx context.Context, storageName string, filePath string, content []byte, mode os.FileMode) error {
+	filePath, err := storageRelativePath(filePath)
+	if err != nil {
+		return err
+	}
+
+	localDirectory, err := os.MkdirTemp("", "storage-file")
+	if err != nil {
+		return fmt.Errorf("unable to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(localDirectory)
+
+	localPath := filepath.Join(localDirectory, filepath.FromSlash(filePath))
+	err = os.MkdirAll(filepath.Dir(localPath), 0755)
+	if err != nil {
+		return fmt.Errorf("unable to create temp directory: %w", err)
+	}
+	err = os.WriteFile(localPath, content, mode)
+	if err != nil {
+		return fmt.Errorf("unable to write temp file: %w", err)
+	}
+	// mode passed to WriteFile is affected by umask
+	err = os.Chmod(localPath, mode)
+	if err != nil {
+		return fmt.Errorf("unable to set permissions for temp file: %w", err)
+	}
+
+	return c.storageSyncDirectories(ctx, storageName, localDirectory, getPathToMount(storageName))
+}
+
+// StorageFileStat returns sha256 checksum and octal permissions of file in storage.
+// File is checked directly on host if ssh user is allowed to run shell commands, otherwise helper app is used.
+func (c *Client) StorageFileStat(ctx context.Context, storageName string, filePath string) (exists bool, checksum string, mode string, err error) {
+	filePath, err = storageRelativePath(filePath)
+	if err != nil {
+		return false, "", "", err
+	}
+
+	hostPath := path.Join(StorageHostPath(storageName), filePath)
+	output, status, err := c.runOnHost(ctx, fmt.Sprintf(`test -f '%s' && sha256sum '%s' && stat -c "__TF_MODE__%%a" '%s'`, hostPath, hostPath, hostPath))
+	if errors.Is(err, ErrHostShellUnavailable) {
+		return c.storageFileStatInHelperApp(ctx, storageName, filePath)
+	}
+	if err != nil {
+		if status == 1 && output == "" {
+			return false, "", "", nil
+		}
+		return false, "", "", fmt.Errorf("unable to read file: %w", err)
+	}
+
+	checksumMatch := storageFileChecksumRe.FindStringSubmatch(output)
+	modeMatch := storageFileModeRe.FindStringSubmatch(output)
+	if checksumMatch == nil || modeMatch == nil {
+		return false, "", "", fmt.Errorf("unable to read file: unexpected output")
+	}
+	return true, checksumMatch[1], modeMatch[1], nil
+}
+
+func (c *Client) storageFileStatInHelperApp(ctx context.Context, storageName string, filePath string) (exists bool, checksum string, mode string, err error) {
+	err = c.withStorageHelperApp(ctx, storageName, func(appName string) error {
+		output, err := c.runInHelperShell(ctx, appName, []string{
+			fmt.Sprintf("[ -f '/mnt/%s' ] && sha256sum '/mnt/%s'", filePath, filePath),
+			fmt.Sprintf(`[ -f '/mnt/%s' ] && stat -c "__TF_MODE__%%a" '/mnt/%s'`, filePath, filePath),
+		})
+		if err != nil {
+			return err
+		}
+
+		checksumMatch := storageFileChecksumRe.FindStringSubmatch(output)
+		modeMatch := storageFileModeRe.FindStringSubmatch(output)
+		if checksumMatch == nil || modeMatch == nil {
+			return nil
+		}
+
+		exists = true
+		checksum = checksumMatch[1]
+		mode = modeMatch[1]
+		return nil
+	})
+	return
+}
+
+// StorageFileRemove removes file from storage.
+func (c *Client) StorageFileRemove(ctx context.Context, storageName string, filePath string) error {
+	filePath, err := storageRelativePath(filePath)
+	if err != nil {
+		return err
+	}
+	if filePath == "." {
+		return fmt.Errorf("path must point to file")
+	}
+
+	return c.withStorageHelperApp(ctx, storageName, func(appName string) error {
+		_, _, err := c.Run(ctx, fmt.Sprintf("run %s rm -f /mnt/%s", appName, filePath))
+		if err != nil {
+			return fmt.Errorf("unable to remove file: %w", err)
+		}
+		return nil
+	})
+}
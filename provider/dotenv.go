@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var dotenvKeyRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// readDotenvFile reads env vars from local file in .env format.
+func readDotenvFile(filePath string) (map[string]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", filePath, err)
+	}
+	res, err := parseDotenv(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", filePath, err)
+	}
+	return res, nil
+}
+
+// parseDotenv parses env vars in .env format.
+// Lines could be prefixed with "export", values could be single-quoted (as is) or double-quoted (with escape sequences), quoted values could be multiline.
+// Comments start with "#" at the beginning of line or after whitespace following value.
+func parseDotenv(content string) (map[string]string, error) {
+	res := make(map[string]string)
+	rest := strings.ReplaceAll(content, "\r\n", "\n")
+	lineNumber := 0
+	for len(rest) > 0 {
+		var line string
+		line, rest, _ = strings.Cut(rest, "\n")
+		lineNumber++
+		startLineNumber := lineNumber
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "export ") || strings.HasPrefix(line, "export\t") {
+			line = strings.TrimSpace(line[len("export"):])
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", startLineNumber)
+		}
+		key = strings.TrimSpace(key)
+		if !dotenvKeyRegexp.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid name %q", startLineNumber, key)
+		}
+		value = strings.TrimLeft(value, " \t")
+
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			// comment in unquoted value should be separated by whitespace
+			if strings.HasPrefix(value, "#") {
+				value = ""
+			} else if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			} else if i := strings.Index(value, "\t#"); i >= 0 {
+				value = value[:i]
+			}
+			res[key] = strings.TrimSpace(value)
+			continue
+		}
+
+		// quoted value could span multiple lines
+		quote := value[0]
+		value = value[1:]
+		for {
+			end := dotenvClosingQuoteIndex(value, quote)
+			if end >= 0 {
+				trailing := strings.TrimSpace(value[end+1:])
+				if trailing != "" && !strings.HasPrefix(trailing, "#") {
+					return nil, fmt.Errorf("line %d: unexpected characters after quoted value", lineNumber)
+				}
+				value = value[:end]
+				break
+			}
+			if len(rest) == 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value", startLineNumber)
+			}
+			var nextLine string
+			nextLine, rest, _ = strings.Cut(rest, "\n")
+			lineNumber++
+			value += "\n" + nextLine
+		}
+		if quote == '"' {
+			value = dotenvUnescape(value)
+		}
+		res[key] = value
+	}
+	return res, nil
+}
+
+func dotenvClosingQuoteIndex(value string, quote byte) int {
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && quote == '"':
+			// escaped character
+			i++
+		case value[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+func dotenvUnescape(value string) string {
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			sb.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case '"', '\\', '$', '`':
+			sb.WriteByte(value[i])
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(value[i])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "unquoted",
+			content: "A=1\nB = two words \r\nC=",
+			want:    map[string]string{"A": "1", "B": "two words", "C": ""},
+		},
+		{
+			name:    "quoted",
+			content: "A='single \\n $X'\nB=\"double \\n \\\"q\\\"\"",
+			want:    map[string]string{"A": "single \\n $X", "B": "double \n \"q\""},
+		},
+		{
+			name:    "multiline",
+			content: "KEY=\"-----BEGIN-----\nline\n-----END-----\"\nB='x\ny'",
+			want:    map[string]string{"KEY": "-----BEGIN-----\nline\n-----END-----", "B": "x\ny"},
+		},
+		{
+			name:    "export",
+			content: "export A=1\nexport\tB=2",
+			want:    map[string]string{"A": "1", "B": "2"},
+		},
+		{
+			name:    "comments",
+			content: "# comment\n\n  # indented comment\nA=1 # comment\nB=a#b\nC=\"c # d\" # comment\nD=#",
+			want:    map[string]string{"A": "1", "B": "a#b", "C": "c # d", "D": ""},
+		},
+		{
+			name:    "missing value",
+			content: "A",
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			content: "1A=1",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quote",
+			content: "A=\"1\nB=2",
+			wantErr: true,
+		},
+		{
+			name:    "characters after quoted value",
+			content: "A='1' 2",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDotenv(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource                = &globalConfigResource{}
+	_ resource.ResourceWithConfigure   = &globalConfigResource{}
+	_ resource.ResourceWithImportState = &globalConfigResource{}
+)
+
+func NewGlobalConfigResource() resource.Resource {
+	return &globalConfigResource{}
+}
+
+type globalConfigResource struct {
+	client *dokkuclient.Client
+}
+
+type globalConfigResourceModel struct {
+	Config map[string]types.String `tfsdk:"config"`
+}
+
+// Metadata returns the resource type name.
+func (r *globalConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_config"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *globalConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *globalConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Global config (env vars) that is applied to all apps, i.e. DOKKU_LETSENCRYPT_EMAIL",
+			"Only keys set here are managed, so it can be used together with other dokku_global_config resources for different keys.",
+			"Apps are not restarted, so changes are applied on next deploy or restart of app.",
+			"On import all keys except DOKKU_* keys and GIT_REV are read, such keys could be added to config manually.",
+			"https://dokku.com/docs/configuration/environment-variables/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"config": schema.MapAttribute{
+				Required:    true,
+				Description: "Global config (env vars)",
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`), "invalid name")),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *globalConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state globalConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	imported, diags := req.Private.GetKey(ctx, appImportedPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GlobalConfigExport(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to get global config", "Unable to get global config. "+err.Error())
+		return
+	}
+	cfg := make(map[string]types.String)
+	for k, v := range config {
+		_, known := state.Config[k]
+		// only known keys, or all keys except set by dokku itself on import
+		if known || (imported != nil && !isDokkuConfigKey(k)) {
+			cfg[k] = basetypes.NewStringValue(v)
+		}
+	}
+	state.Config = cfg
+
+	if imported != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, appImportedPrivateKey, nil)...)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *globalConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan globalConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := make(map[string]string)
+	for k, v := range plan.Config {
+		config[k] = v.ValueString()
+	}
+	if len(config) != 0 {
+		err := r.client.GlobalConfigSet(ctx, config)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to set global config", "Unable to set global config. "+err.Error())
+			return
+		}
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *globalConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan globalConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state globalConfigResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var namesToUnset []string
+	for stateName := range state.Config {
+		if _, found := plan.Config[stateName]; !found {
+			namesToUnset = append(namesToUnset, stateName)
+		}
+	}
+	if len(namesToUnset) != 0 {
+		err := r.client.GlobalConfigUnset(ctx, namesToUnset)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to unset global config", "Unable to unset global config. "+err.Error())
+			return
+		}
+	}
+
+	configToSet := make(map[string]string)
+	for k, v := range plan.Config {
+		if !state.Config[k].Equal(v) {
+			configToSet[k] = v.ValueString()
+		}
+	}
+	if len(configToSet) != 0 {
+		err := r.client.GlobalConfigSet(ctx, configToSet)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to set global config", "Unable to set global config. "+err.Error())
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *globalConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state globalConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(state.Config) == 0 {
+		return
+	}
+
+	var names []string
+	for k := range state.Config {
+		names = append(names, k)
+	}
+	err := r.client.GlobalConfigUnset(ctx, names)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "Unable to unset global config", "Unable to unset global config. "+err.Error())
+		return
+	}
+}
+
+func (r *globalConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID is not used, all global config keys are imported
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("config"), map[string]string{})...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, appImportedPrivateKey, []byte("true"))...)
+}
@@ -9,6 +9,7 @@ import (
 	"os/user"
 	"path/filepath"
 	"strings"
+	"time"
 
 	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
 	"github.com/aliksend/terraform-provider-dokku/provider/services"
@@ -49,6 +50,7 @@ type dokkuProviderModel struct {
 	LogSshCommands      types.Bool   `tfsdk:"log_ssh_commands"`
 	UploadAppName       types.String `tfsdk:"upload_app_name"`
 	UploadSplitBytes    types.Int64  `tfsdk:"upload_split_bytes"`
+	LockWaitTimeout     types.Int64  `tfsdk:"lock_wait_timeout_seconds"`
 }
 
 func (p *dokkuProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -114,6 +116,7 @@ func (p *dokkuProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional: true,
 				Description: strings.Join([]string{
 					"This attribute is used to upload local files to remote server using storage.local_directory attribute.",
+					"Also it is used by dokku_storage resource and dokku_storage_download data source to access storage directories.",
 					"App name to use for local file synchronization. Default: storage-sync",
 					"",
 					"Since dokku don't allow to upload files directly, workaround is used.",
@@ -146,6 +149,13 @@ func (p *dokkuProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 					int64validator.AtLeast(1),
 				},
 			},
+			"lock_wait_timeout_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time (in seconds) to wait if command that deploys or restarts app (i.e. git:from-image, config:set, ps:restart) fails because app is locked by another deploy (i.e. by git push or apps:lock). Command is retried every 5 seconds, other commands are not retried. Set 0 to fail immediately. Default: 60",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 		},
 	}
 }
@@ -205,6 +215,7 @@ func (p *dokkuProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	logSshCommands := false
 	uploadAppName := "storage-sync"
 	uploadSplitBytes := 256
+	lockWaitTimeout := 60 * time.Second
 
 	if !config.SshHost.IsNull() {
 		host = config.SshHost.ValueString()
@@ -232,6 +243,9 @@ func (p *dokkuProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	if !config.UploadSplitBytes.IsNull() {
 		uploadSplitBytes = int(config.UploadSplitBytes.ValueInt64())
 	}
+	if !config.LockWaitTimeout.IsNull() {
+		lockWaitTimeout = time.Duration(config.LockWaitTimeout.ValueInt64()) * time.Second
+	}
 
 	usr, err := user.Current()
 	if err == nil {
@@ -303,7 +317,7 @@ func (p *dokkuProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	dokkuClient := dokkuclient.New(client, logSshCommands, uploadAppName, uploadSplitBytes)
+	dokkuClient := dokkuclient.New(client, logSshCommands, uploadAppName, uploadSplitBytes, lockWaitTimeout)
 	rawVersion, version, err := dokkuClient.GetVersion(ctx)
 	if err != nil {
 		if err == dokkuclient.ErrInvalidUser {
@@ -341,11 +355,21 @@ func (p *dokkuProvider) Configure(ctx context.Context, req provider.ConfigureReq
 func (p *dokkuProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAppResource,
+		NewAppConfigResource,
+		NewAppPortsResource,
+		NewAppDomainsResource,
+		NewAppStorageMountResource,
+		NewAppDockerOptionResource,
+		NewAppNetworkResource,
+		NewRunResource,
 		NewDomainResource,
+		NewGlobalConfigResource,
 		NewHttpAuthResource,
 		NewLetsencryptResource,
 		NewPluginResource,
 		NewNginxConfigResource,
+		NewStorageResource,
+		NewStorageFileResource,
 
 		services.NewClickhouseLinkResource,
 		services.NewClickhouseResource,
@@ -373,7 +397,10 @@ func (p *dokkuProvider) Resources(ctx context.Context) []func() resource.Resourc
 }
 
 func (p *dokkuProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewStorageDownloadDataSource,
+		NewAppCronDataSource,
+	}
 }
 
 func verifyHost(host string, remote net.Addr, key ssh.PublicKey) error {
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"regexp"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource               = &runResource{}
+	_ resource.ResourceWithConfigure  = &runResource{}
+	_ resource.ResourceWithModifyPlan = &runResource{}
+	// _ resource.ResourceWithImportState = &runResource{} // command is run only by terraform.
+)
+
+// runOutputMaxLength is max length of stdout saved to state. Only the end of output is saved.
+const runOutputMaxLength = 4096
+
+func NewRunResource() resource.Resource {
+	return &runResource{}
+}
+
+type runResource struct {
+	client *dokkuclient.Client
+}
+
+type runResourceModel struct {
+	AppName          types.String            `tfsdk:"app_name"`
+	Command          types.String            `tfsdk:"command"`
+	CronId           types.String            `tfsdk:"cron_id"`
+	Triggers         map[string]types.String `tfsdk:"triggers"`
+	Detached         types.Bool              `tfsdk:"detached"`
+	RunOnCreateOnly  types.Bool              `tfsdk:"run_on_create_only"`
+	IgnoreExitStatus types.Bool              `tfsdk:"ignore_exit_status"`
+	Redact           types.Set               `tfsdk:"redact"`
+	ExitStatus       types.Int64             `tfsdk:"exit_status"`
+	Stdout           types.String            `tfsdk:"stdout"`
+}
+
+// shouldRun reports whether command should be run again on update.
+func (m runResourceModel) shouldRun(state runResourceModel) bool {
+	if m.RunOnCreateOnly.ValueBool() {
+		return false
+	}
+	return !m.Command.Equal(state.Command) || !m.CronId.Equal(state.CronId) || !maps.EqualFunc(m.Triggers, state.Triggers, func(a types.String, b types.String) bool {
+		return a.Equal(b)
+	})
+}
+
+// Metadata returns the resource type name.
+func (r *runResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_run"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *runResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *runResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"One-off command or cron task run in new container of deployed app, i.e. database migration",
+			"",
+			"Command is run on create and again when command, cron_id or triggers are changed. Nothing is done on delete.",
+			"https://dokku.com/docs/processes/one-off-tasks/",
+			"https://dokku.com/docs/processes/scheduled-cron-tasks/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of application to run command for",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "invalid app_name"),
+				},
+			},
+			"command": schema.StringAttribute{
+				Optional:    true,
+				Description: "Command to run, i.e. \"npm run migrate\"",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("cron_id")),
+				},
+			},
+			"cron_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of cron task to run, see dokku_app_cron data source",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				Description: "Arbitrary values that cause command to run again when changed, i.e. { deployed_at = dokku_app.demo.deployed_at }",
+				ElementType: types.StringType,
+			},
+			"detached": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Run command in background using run:detached (or cron:run --detach). Exit status and output of command itself are not available in this case. Default: false",
+			},
+			"run_on_create_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Run command only when resource is created, changes of command and triggers are ignored. Default: false",
+			},
+			"ignore_exit_status": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Do not fail if command exits with non-zero status, exit status is saved to exit_status attribute instead. Default: false",
+			},
+			"redact": schema.SetAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Strings to replace with asterisks in command logs and in stdout attribute, i.e. secrets printed by command",
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"exit_status": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Exit status of last run of command",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"stdout": schema.StringAttribute{
+				Computed:    true,
+				Description: "Output of last run of command. Only last 4096 bytes are saved",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan marks results of command as unknown if command will be run again.
+func (r *runResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan runResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var state runResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.shouldRun(state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("exit_status"), basetypes.NewInt64Unknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("stdout"), basetypes.NewStringUnknown())...)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *runResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state runResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check app existence, so command is run again for recreated app
+	exists, err := r.client.AppExists(ctx, state.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_name"), "Unable to check app existence", "Unable to check app existence. "+err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *runResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan runResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.run(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *runResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan runResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state runResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.shouldRun(state) {
+		resp.Diagnostics.Append(r.run(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *runResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// command could not be reverted, so resource is only removed from state
+}
+
+// run runs command and saves its results to model.
+func (r *runResource) run(ctx context.Context, model *runResourceModel) (diags diag.Diagnostics) {
+	var stdout string
+	var status int
+	var err error
+	if !model.CronId.IsNull() {
+		stdout, status, err = r.client.CronRun(ctx, model.AppName.ValueString(), model.CronId.ValueString(), model.Detached.ValueBool(), formatStringSet(model.Redact)...)
+	} else {
+		stdout, status, err = r.client.AppRun(ctx, model.AppName.ValueString(), model.Command.ValueString(), model.Detached.ValueBool(), formatStringSet(model.Redact)...)
+	}
+	// zero status with error means that command was not run at all
+	if err != nil && (status == 0 || !model.IgnoreExitStatus.ValueBool()) {
+		diags.AddError("Unable to run command", "Unable to run command. "+err.Error())
+		return
+	}
+
+	if len(stdout) > runOutputMaxLength {
+		// output could be cut in the middle of multibyte character
+		stdout = strings.ToValidUTF8(stdout[len(stdout)-runOutputMaxLength:], "")
+	}
+	model.ExitStatus = basetypes.NewInt64Value(int64(status))
+	model.Stdout = basetypes.NewStringValue(stdout)
+	return
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ datasource.DataSource              = &storageDownloadDataSource{}
+	_ datasource.DataSourceWithConfigure = &storageDownloadDataSource{}
+)
+
+const storageDownloadDefaultMaxSizeBytes = 10 * 1024 * 1024
+
+func NewStorageDownloadDataSource() datasource.DataSource {
+	return &storageDownloadDataSource{}
+}
+
+type storageDownloadDataSource struct {
+	client *dokkuclient.Client
+}
+
+type storageDownloadDataSourceModel struct {
+	Storage        types.String   `tfsdk:"storage"`
+	Path           types.String   `tfsdk:"path"`
+	LocalDirectory types.String   `tfsdk:"local_directory"`
+	MaxSizeBytes   types.Int64    `tfsdk:"max_size_bytes"`
+	Files          []types.String `tfsdk:"files"`
+	SizeBytes      types.Int64    `tfsdk:"size_bytes"`
+	Content        types.String   `tfsdk:"content"`
+	ContentBase64  types.String   `tfsdk:"content_base64"`
+}
+
+// Metadata returns the data source type name.
+func (d *storageDownloadDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_download"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *storageDownloadDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	d.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the data source.
+func (d *storageDownloadDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Downloads file or directory from persistent storage",
+			"",
+			"Uses the same helper app mechanism as storage.local_directory attribute of dokku_app, see upload_app_name attribute in provider configuration.",
+			"Downloaded data is transferred as base64-encoded tar archive, so it should not be used for large files.",
+			"Helper app is created, deployed and destroyed on every read of data source (on every plan and refresh), so it takes time and it is better to keep data source in configuration only while downloaded data is needed.",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"storage": schema.StringAttribute{
+				Required:    true,
+				Description: "Storage name or absolute path to host directory",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to file or directory inside storage. Default: whole storage",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"local_directory": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local directory to save downloaded files to. Content of directory is saved as is, single file is saved with its name. Files that are not changed are not written again",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"max_size_bytes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Max size of downloaded tar archive. Default: 10485760 (10 MiB)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"files": schema.ListAttribute{
+				Computed:    true,
+				Description: "Downloaded files. Paths are relative to path attribute",
+				ElementType: types.StringType,
+			},
+			"size_bytes": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Total size of downloaded files",
+			},
+			"content": schema.StringAttribute{
+				Computed:    true,
+				Description: "Content of downloaded file. Set only if path points to file",
+			},
+			"content_base64": schema.StringAttribute{
+				Computed:    true,
+				Description: "Base64-encoded content of downloaded file. Set only if path points to file",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *storageDownloadDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data storageDownloadDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remotePath := "."
+	if !data.Path.IsNull() {
+		remotePath = data.Path.ValueString()
+	}
+	maxSizeBytes := int64(storageDownloadDefaultMaxSizeBytes)
+	if !data.MaxSizeBytes.IsNull() {
+		maxSizeBytes = data.MaxSizeBytes.ValueInt64()
+	}
+
+	files, isDirectory, err := d.client.StorageDownload(ctx, data.Storage.ValueString(), remotePath, maxSizeBytes)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Unable to download storage", "Unable to download storage. "+err.Error())
+		return
+	}
+
+	if !data.LocalDirectory.IsNull() {
+		err := dokkuclient.StorageFilesWriteToDirectory(files, data.LocalDirectory.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("local_directory"), "Unable to save downloaded files", "Unable to save downloaded files. "+err.Error())
+			return
+		}
+	}
+
+	var sizeBytes int64
+	data.Files = make([]types.String, len(files))
+	for i, f := range files {
+		data.Files[i] = basetypes.NewStringValue(f.Name)
+		sizeBytes += int64(len(f.Content))
+	}
+	data.SizeBytes = basetypes.NewInt64Value(sizeBytes)
+
+	if !isDirectory && len(files) == 1 {
+		data.Content = basetypes.NewStringValue(string(files[0].Content))
+		data.ContentBase64 = basetypes.NewStringValue(base64.StdEncoding.EncodeToString(files[0].Content))
+	} else {
+		data.Content = basetypes.NewStringNull()
+		data.ContentBase64 = basetypes.NewStringNull()
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
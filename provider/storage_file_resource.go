@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource               = &storageFileResource{}
+	_ resource.ResourceWithConfigure  = &storageFileResource{}
+	_ resource.ResourceWithModifyPlan = &storageFileResource{}
+	// _ resource.ResourceWithImportState = &storageFileResource{} // content is defined only in configuration.
+)
+
+func NewStorageFileResource() resource.Resource {
+	return &storageFileResource{}
+}
+
+type storageFileResource struct {
+	client *dokkuclient.Client
+}
+
+type storageFileResourceModel struct {
+	Storage          types.String `tfsdk:"storage"`
+	Path             types.String `tfsdk:"path"`
+	Content          types.String `tfsdk:"content"`
+	SensitiveContent types.String `tfsdk:"sensitive_content"`
+	Mode             types.String `tfsdk:"mode"`
+	Checksum         types.String `tfsdk:"checksum"`
+}
+
+func (m storageFileResourceModel) content() types.String {
+	if !m.SensitiveContent.IsNull() {
+		return m.SensitiveContent
+	}
+	return m.Content
+}
+
+func (m storageFileResourceModel) fileMode() (os.FileMode, error) {
+	if m.Mode.IsNull() {
+		return 0644, nil
+	}
+	mode, err := strconv.ParseUint(m.Mode.ValueString(), 8, 32)
+	if err != nil {
+		return 0, err
+	}
+	return os.FileMode(mode), nil
+}
+
+func storageFileChecksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// Metadata returns the resource type name.
+func (r *storageFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_file"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *storageFileResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *storageFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"Single file inside persistent storage",
+			"",
+			"File is uploaded the same way as storage.local_directory attribute of dokku_app, see upload_app_name attribute in provider configuration.",
+			"Checksum of remote file is checked on every refresh using sha256sum over ssh (or using helper app if ssh user is restricted to dokku commands), so changes made outside of terraform are detected.",
+			"Storage directory is not ensured on upload, so its ownership is kept and uploaded file is owned by root.",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"storage": schema.StringAttribute{
+				Required:    true,
+				Description: "Storage name or absolute path to host directory",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "Path to file inside storage",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^/'\s][^'\s]*$`), "must be relative path without quotes and spaces"),
+				},
+			},
+			"content": schema.StringAttribute{
+				Optional:    true,
+				Description: "Content of file. Use templatefile() to render content from template",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("sensitive_content")),
+				},
+			},
+			"sensitive_content": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Content of file that will not be displayed in plan output",
+			},
+			"mode": schema.StringAttribute{
+				Optional:    true,
+				Description: "Permissions to set for file in octal format. Default: 0644",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^0[0-7]{3}$`), "must be octal, i.e. 0644"),
+				},
+			},
+			"checksum": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA256 checksum of file content",
+			},
+		},
+	}
+}
+
+// ModifyPlan calculates checksum of planned content, so changes of remote file cause update.
+func (r *storageFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan storageFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content := plan.content()
+	if content.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("checksum"), basetypes.NewStringUnknown())...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("checksum"), storageFileChecksum(content.ValueString()))...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *storageFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state storageFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, checksum, mode, err := r.client.StorageFileStat(ctx, state.Storage.ValueString(), state.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read storage file", "Unable to read storage file. "+err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Checksum = basetypes.NewStringValue(checksum)
+	// only if mode is managed
+	if !state.Mode.IsNull() {
+		for len(mode) < 4 {
+			mode = "0" + mode
+		}
+		state.Mode = basetypes.NewStringValue(mode)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *storageFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan storageFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.upload(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *storageFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan storageFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.upload(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *storageFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state storageFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.StorageFileRemove(ctx, state.Storage.ValueString(), state.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to remove storage file", "Unable to remove storage file. "+err.Error())
+		return
+	}
+}
+
+func (r *storageFileResource) upload(ctx context.Context, plan *storageFileResourceModel, diags *diag.Diagnostics) {
+	mode, err := plan.fileMode()
+	if err != nil {
+		diags.AddAttributeError(path.Root("mode"), "Invalid mode", "Invalid mode. "+err.Error())
+		return
+	}
+
+	content := plan.content().ValueString()
+	err = r.client.StorageFileUpload(ctx, plan.Storage.ValueString(), plan.Path.ValueString(), []byte(content), mode)
+	if err != nil {
+		diags.AddError("Unable to upload storage file", "Unable to upload storage file. "+err.Error())
+		return
+	}
+
+	plan.Checksum = basetypes.NewStringValue(storageFileChecksum(content))
+}
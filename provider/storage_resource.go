@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	dokkuclient "github.com/aliksend/terraform-provider-dokku/provider/dokku_client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.Resource                = &storageResource{}
+	_ resource.ResourceWithConfigure   = &storageResource{}
+	_ resource.ResourceWithImportState = &storageResource{}
+)
+
+func NewStorageResource() resource.Resource {
+	return &storageResource{}
+}
+
+type storageResource struct {
+	client *dokkuclient.Client
+}
+
+type storageResourceModel struct {
+	Name            types.String `tfsdk:"name"`
+	Chown           types.String `tfsdk:"chown"`
+	DeleteOnDestroy types.Bool   `tfsdk:"delete_on_destroy"`
+	HostPath        types.String `tfsdk:"host_path"`
+}
+
+// Metadata returns the resource type name.
+func (r *storageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *storageResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	//nolint:forcetypeassert
+	r.client = req.ProviderData.(*dokkuclient.Client)
+}
+
+// Schema defines the schema for the resource.
+func (r *storageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: strings.Join([]string{
+			"dokku persistent storage directory",
+			"Use host_path as key of dokku_app.storage to mount the same storage to multiple apps with explicit dependency",
+			"Existence of directory is checked on refresh using test command over ssh. If ssh user is restricted to dokku commands, helper app is created for the check the same way as for storage.local_directory attribute of dokku_app, see upload_app_name attribute in provider configuration",
+			"https://dokku.com/docs/advanced-usage/persistent-storage/",
+		}, "\n  "),
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of storage directory to create in /var/lib/dokku/data/storage",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9_-]+$`), "invalid name"),
+				},
+			},
+			"chown": schema.StringAttribute{
+				Optional:    true,
+				Description: "Ownership to set for directory. Allowed values: herokuish, heroku, packeto, paketo, root, false. Default: herokuish",
+				Validators: []validator.String{
+					stringvalidator.OneOf("herokuish", "heroku", "packeto", "paketo", "root", "false"),
+				},
+			},
+			"delete_on_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Remove directory with all its content from host when resource is destroyed. Default: false",
+			},
+			"host_path": schema.StringAttribute{
+				Computed:    true,
+				Description: "Absolute path to directory on host",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *storageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state storageResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check directory existence
+	exists, err := r.client.StorageDirectoryExists(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to check storage existence", "Unable to check storage existence. "+err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.HostPath = basetypes.NewStringValue(dokkuclient.StorageHostPath(state.Name.ValueString()))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *storageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan storageResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create directory
+	err := r.client.StorageEnsureDirectory(ctx, plan.Name.ValueString(), plan.Chown.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to ensure storage", "Unable to ensure storage. "+err.Error())
+		return
+	}
+
+	plan.HostPath = basetypes.NewStringValue(dokkuclient.StorageHostPath(plan.Name.ValueString()))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *storageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan storageResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state storageResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Name.ValueString() != state.Name.ValueString() {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Storage name can't be changed", "Storage name can't be changed")
+		return
+	}
+
+	if !plan.Chown.Equal(state.Chown) {
+		err := r.client.StorageEnsureDirectory(ctx, plan.Name.ValueString(), plan.Chown.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("chown"), "Unable to ensure storage", "Unable to ensure storage. "+err.Error())
+			return
+		}
+	}
+
+	plan.HostPath = basetypes.NewStringValue(dokkuclient.StorageHostPath(plan.Name.ValueString()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *storageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state storageResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep directory with its data unless asked explicitly
+	if !state.DeleteOnDestroy.ValueBool() {
+		return
+	}
+
+	err := r.client.StorageDirectoryDestroy(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to destroy storage", "Unable to destroy storage. "+err.Error())
+		return
+	}
+}
+
+func (r *storageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to name attribute
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}